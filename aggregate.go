@@ -0,0 +1,243 @@
+package sqlbuilder
+
+import "fmt"
+
+type aggKind int
+
+const (
+	aggPlain aggKind = iota
+	aggGroupConcat
+	aggJSONArray
+)
+
+// Count counts the rows, COUNT(*) without field.
+func Count(fields ...any) *AggExpr {
+	return &AggExpr{name: "COUNT", operands: fields}
+}
+
+// CountDistinct counts the distinct values of the fields, only MySQL accepts more than one field.
+func CountDistinct(fields ...any) *AggExpr {
+	return &AggExpr{name: "COUNT", operands: fields, distinct: true}
+}
+
+func Sum(field any) *AggExpr {
+	return &AggExpr{name: "SUM", operands: []any{field}}
+}
+
+func Avg(field any) *AggExpr {
+	return &AggExpr{name: "AVG", operands: []any{field}}
+}
+
+func Min(field any) *AggExpr {
+	return &AggExpr{name: "MIN", operands: []any{field}}
+}
+
+func Max(field any) *AggExpr {
+	return &AggExpr{name: "MAX", operands: []any{field}}
+}
+
+// GroupConcat concatenates the values of the field, separated by a comma unless
+// Separator is given. It is rendered as GROUP_CONCAT on MySQL and SQLite, as
+// STRING_AGG on PostgreSQL and SQL Server and as LISTAGG on Oracle.
+func GroupConcat(field any) *AggExpr {
+	return &AggExpr{kind: aggGroupConcat, operands: []any{field}}
+}
+
+// StringAgg is GroupConcat with a separator.
+func StringAgg(field any, separator string) *AggExpr {
+	return GroupConcat(field).Separator(separator)
+}
+
+// JSONArrayAgg aggregates the values of the field into a JSON array. It is rendered
+// as JSON_AGG on PostgreSQL and as JSON_GROUP_ARRAY on SQLite.
+func JSONArrayAgg(field any) *AggExpr {
+	return &AggExpr{kind: aggJSONArray, operands: []any{field}}
+}
+
+// AggExpr is an aggregate function call.
+type AggExpr struct {
+	_expression
+	kind      aggKind
+	name      string
+	operands  []any
+	distinct  bool
+	filter    []whereCondition
+	orderBy   []*OrderSpec
+	separator *string
+	Alias     string
+}
+
+// Distinct aggregates the distinct values only.
+func (a *AggExpr) Distinct() *AggExpr {
+	a.distinct = true
+	return a
+}
+
+// Filter aggregates the rows matching the conditions only. It is rendered as
+// FILTER (WHERE ...) on PostgreSQL and SQLite and emulated with CASE elsewhere.
+func (a *AggExpr) Filter(conditions ...whereCondition) *AggExpr {
+	a.filter = append(a.filter, conditions...)
+	return a
+}
+
+// OrderBy orders the aggregated values, e.g. of GroupConcat.
+func (a *AggExpr) OrderBy(orderSpecs ...*OrderSpec) *AggExpr {
+	a.orderBy = append(a.orderBy, orderSpecs...)
+	return a
+}
+
+// Separator separates the values concatenated by GroupConcat.
+func (a *AggExpr) Separator(separator string) *AggExpr {
+	a.separator = &separator
+	return a
+}
+
+// As names the aggregate when it is selected.
+func (a *AggExpr) As(alias string) *AggExpr {
+	a.Alias = alias
+	return a
+}
+
+func (a *AggExpr) funcName(d Dialect) string {
+	switch a.kind {
+	case aggGroupConcat:
+		switch d {
+		case PostgreSQL, SQLServer:
+			return "STRING_AGG"
+		case Oracle:
+			return "LISTAGG"
+		}
+		return "GROUP_CONCAT"
+	case aggJSONArray:
+		switch d {
+		case PostgreSQL:
+			return "JSON_AGG"
+		case SQLite:
+			return "JSON_GROUP_ARRAY"
+		}
+		return "JSON_ARRAYAGG"
+	}
+	return a.name
+}
+
+// nativeFilter reports whether the dialect supports FILTER (WHERE ...).
+func nativeFilter(d Dialect) bool {
+	return d == PostgreSQL || d == SQLite
+}
+
+// withinGroup reports whether the dialect orders the aggregated values with
+// WITHIN GROUP (ORDER BY ...) instead of inside the call.
+func (a *AggExpr) withinGroup(d Dialect) bool {
+	return a.kind == aggGroupConcat && (d == SQLServer || d == Oracle)
+}
+
+func (a *AggExpr) write(buf *buffer) {
+	d := buf.dialect
+	emulated := len(a.filter) > 0 && !nativeFilter(d)
+	a.check(buf, emulated)
+
+	buf.WriteString(a.funcName(d))
+	buf.OpenParen()
+	if a.distinct {
+		buf.WriteString("DISTINCT ")
+	}
+	if len(a.operands) == 0 {
+		if emulated {
+			a.writeFilterCase(buf, func() { buf.WriteByte('1') })
+		} else {
+			buf.WriteByte('*')
+		}
+	}
+	for i, op := range a.operands {
+		if i > 0 {
+			buf.Comma()
+		}
+		if emulated {
+			a.writeFilterCase(buf, func() { buf.Operand(op) })
+		} else {
+			buf.Operand(op)
+		}
+	}
+	if a.kind == aggGroupConcat && !d.isMySQL() {
+		buf.Comma()
+		buf.StringLiteral(a.sep())
+	}
+	if len(a.orderBy) > 0 && !a.withinGroup(d) {
+		buf.WriteString(" ORDER BY ")
+		buf.OrderSpecs(a.orderBy)
+	}
+	if a.kind == aggGroupConcat && d.isMySQL() {
+		buf.WriteString(" SEPARATOR ")
+		buf.StringLiteral(a.sep())
+	}
+	buf.CloseParen()
+	if a.withinGroup(d) && (len(a.orderBy) > 0 || d == Oracle) {
+		buf.WriteString(" WITHIN GROUP (ORDER BY ")
+		if len(a.orderBy) > 0 {
+			buf.OrderSpecs(a.orderBy)
+		} else {
+			// LISTAGG requires an order before Oracle 19c.
+			buf.WriteString("NULL")
+		}
+		buf.CloseParen()
+	}
+	if len(a.filter) > 0 && !emulated {
+		buf.WriteString(" FILTER (WHERE ")
+		buf.Conditions(a.filter)
+		buf.CloseParen()
+	}
+}
+
+// check records the combinations the dialect cannot express.
+func (a *AggExpr) check(buf *buffer, emulated bool) {
+	d := buf.dialect
+	switch {
+	case a.distinct && len(a.operands) == 0:
+		buf.setErr(fmt.Errorf("%w: DISTINCT %s", ErrNoField, a.funcName(d)))
+	case len(a.operands) > 1 && !d.isMySQL():
+		buf.setErr(unsupported(d, "aggregate of several fields"))
+	case len(a.orderBy) > 0 && a.kind == aggPlain && d != PostgreSQL && d != SQLite:
+		buf.setErr(unsupported(d, "ordered "+a.name))
+	case a.kind == aggJSONArray && d == SQLServer:
+		buf.setErr(unsupported(d, "JSON_ARRAYAGG"))
+	case a.kind == aggJSONArray && len(a.orderBy) > 0 && (d == MySQL || d == SQLite):
+		buf.setErr(unsupported(d, "ordered JSON_ARRAYAGG"))
+	case a.kind != aggPlain && a.distinct && (d == SQLServer || d == Oracle):
+		buf.setErr(unsupported(d, "DISTINCT "+a.funcName(d)))
+	case emulated && len(a.operands) > 1:
+		buf.setErr(unsupported(d, "FILTER of an aggregate of several fields"))
+	case emulated && len(a.filterArgs(d)) > 0 && len(fieldsArgs(d, a.operands))+len(orderArgs(d, a.orderBy)) > 0:
+		// The emulation writes the conditions before the operands and the order,
+		// which would reorder the args.
+		buf.setErr(unsupported(d, "FILTER of an aggregate of a parameterized expression"))
+	}
+}
+
+func (a *AggExpr) writeFilterCase(buf *buffer, then func()) {
+	buf.WriteString("CASE WHEN ")
+	buf.Conditions(a.filter)
+	buf.WriteString(" THEN ")
+	then()
+	buf.WriteString(" END")
+}
+
+func (a *AggExpr) sep() string {
+	if a.separator == nil {
+		return ","
+	}
+	return *a.separator
+}
+
+func (a *AggExpr) filterArgs(d Dialect) []any {
+	var args []any
+	for _, c := range a.filter {
+		args = append(args, c.args(d)...)
+	}
+	return args
+}
+
+func (a *AggExpr) args(d Dialect) []any {
+	args := fieldsArgs(d, a.operands)
+	args = append(args, orderArgs(d, a.orderBy)...)
+	return append(args, a.filterArgs(d)...)
+}
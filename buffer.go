@@ -32,11 +32,23 @@ func releaseBuffer(buf *buffer) {
 		return
 	}
 	buf.Reset()
+	buf.dialect = MySQL
+	buf.err = nil
+	buf.rowAlias = ""
+	buf.conflictTable = nil
 	bufferPool.Put(buf)
 }
 
 type buffer struct {
 	*bytes.Buffer
+	dialect Dialect
+	err     error
+
+	// rowAlias names the row of an INSERT, see insertBuilderValues.RowAlias.
+	rowAlias string
+	// conflictTable qualifies the columns read by DO UPDATE, which PostgreSQL
+	// finds ambiguous with the columns of EXCLUDED.
+	conflictTable *Table
 }
 
 func newBuffer(length int) *buffer {
@@ -45,6 +57,13 @@ func newBuffer(length int) *buffer {
 	}
 }
 
+// setErr records the first error met while writing the statement.
+func (b *buffer) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
 func (b *buffer) Space() {
 	b.WriteByte(space)
 }
@@ -85,15 +104,73 @@ func (b *buffer) BackQuoteStrings(vals []string) {
 	b.WriteByte(backQuote)
 }
 
+// Quote writes an identifier quoted for the dialect of the buffer.
+func (b *buffer) Quote(val string) {
+	q := b.dialect.quote()
+	b.WriteByte(q)
+	b.WriteString(val)
+	b.WriteByte(q)
+}
+
+func (b *buffer) Quotes(vals []string) {
+	for i, val := range vals {
+		if i > 0 {
+			b.Comma()
+		}
+		b.Quote(val)
+	}
+}
+
 func (b *buffer) Table(t *Table) {
 	if t.Database != "" {
-		b.BackQuoteString(t.Database)
+		b.Quote(t.Database)
 		b.Dot()
 	}
-	b.BackQuoteString(t.Table)
-	if t.Alias != "" {
-		b.WriteString(" AS ")
-		b.BackQuoteString(t.Alias)
+	b.Quote(t.Table)
+	b.TableAlias(t.Alias)
+}
+
+// TableAlias writes the alias of a table or derived table, Oracle does not accept AS there.
+func (b *buffer) TableAlias(alias string) {
+	if alias != "" && b.dialect == Oracle {
+		b.Space()
+		b.Quote(alias)
+		return
+	}
+	b.Alias(alias)
+}
+
+func (b *buffer) Alias(alias string) {
+	if alias == "" {
+		return
+	}
+	b.WriteString(" AS ")
+	b.Quote(alias)
+}
+
+func (b *buffer) Subquery(s *Subquery) {
+	b.OpenParen()
+	b.WriteString(s.Sql)
+	b.CloseParen()
+	b.TableAlias(s.Alias)
+}
+
+// Inserted writes a reference to the column of the row proposed for insertion.
+func (b *buffer) Inserted(column string) {
+	switch {
+	case b.dialect == PostgreSQL || b.dialect == SQLite:
+		b.WriteString("EXCLUDED")
+		b.Dot()
+		b.Quote(column)
+	case b.rowAlias != "":
+		b.Quote(b.rowAlias)
+		b.Dot()
+		b.Quote(column)
+	default:
+		b.WriteString("VALUES")
+		b.OpenParen()
+		b.Quote(column)
+		b.CloseParen()
 	}
 }
 
@@ -108,21 +185,57 @@ func (b *buffer) Tables(tables []*Table) {
 
 func (b *buffer) Expr(e *Expr) {
 	b.WriteString(e.Expr)
-	if e.Alias != "" {
-		b.WriteString(" AS ")
-		b.BackQuoteString(e.Alias)
-	}
+	b.Alias(e.Alias)
 }
 
 func (b *buffer) Field(f *Field) {
+	b.FieldName(f)
+	b.Alias(f.Alias)
+}
+
+// FieldName writes the qualified name of the field without its alias.
+func (b *buffer) FieldName(f *Field) {
 	if f.Table != "" {
-		b.BackQuoteString(f.Table)
+		b.Quote(f.Table)
 		b.Dot()
 	}
-	b.BackQuoteString(f.Field)
-	if f.Alias != "" {
-		b.WriteString(" AS ")
-		b.BackQuoteString(f.Alias)
+	b.Quote(f.Field)
+}
+
+// ValueField writes a column read on the right-hand side of an assignment.
+func (b *buffer) ValueField(f *Field) {
+	if f.Table == "" && b.conflictTable != nil {
+		b.TableRef(b.conflictTable)
+		b.Dot()
+	}
+	b.FieldName(f)
+}
+
+// TableRef writes the name a table is referred to by in the rest of the statement.
+func (b *buffer) TableRef(t *Table) {
+	if t.Alias != "" {
+		b.Quote(t.Alias)
+		return
+	}
+	if t.Database != "" {
+		b.Quote(t.Database)
+		b.Dot()
+	}
+	b.Quote(t.Table)
+}
+
+// SetTarget writes the column on the left side of an assignment, PostgreSQL and SQLite
+// do not accept a table qualifier there.
+func (b *buffer) SetTarget(field any) {
+	switch v := field.(type) {
+	case *Field:
+		if b.dialect == PostgreSQL || b.dialect == SQLite {
+			b.Quote(v.Field)
+		} else {
+			b.FieldName(v)
+		}
+	default:
+		b.AnyField(field)
 	}
 }
 
@@ -132,8 +245,51 @@ func (b *buffer) AnyField(field any) {
 		b.Field(v)
 	case *Expr:
 		b.Expr(v)
+	case *CaseExpr:
+		v.write(b)
+		b.Alias(v.Alias)
+	case *FnExpr:
+		v.write(b)
+		b.Alias(v.Alias)
+	case *AggExpr:
+		v.write(b)
+		b.Alias(v.Alias)
+	case *MatchExpr:
+		v.writeScore(b)
+		b.Alias(v.Alias)
+	case *JSONExpr:
+		v.write(b)
+		b.Alias(v.Alias)
+	case expression:
+		v.write(b)
+	case string:
+		b.Quote(v)
+	}
+}
+
+// Operand writes a field referred to by a condition or an ORDER BY, without its alias.
+func (b *buffer) Operand(field any) {
+	switch v := field.(type) {
+	case *Field:
+		b.FieldName(v)
+	case *MatchExpr:
+		v.writeScore(b)
+	case expression:
+		v.write(b)
 	case string:
-		b.BackQuoteString(v)
+		b.Quote(v)
+	}
+}
+
+// Value writes a value, a placeholder unless it is a column or an expression.
+func (b *buffer) Value(v any) {
+	switch x := v.(type) {
+	case *Field:
+		b.FieldName(x)
+	case expression:
+		x.write(b)
+	default:
+		b.Question()
 	}
 }
 
@@ -146,6 +302,16 @@ func (b *buffer) AnyFields(fields []any) {
 	}
 }
 
+// Operands writes the fields separated by commas, without their aliases.
+func (b *buffer) Operands(fields []any) {
+	for i := range fields {
+		if i > 0 {
+			b.Comma()
+		}
+		b.Operand(fields[i])
+	}
+}
+
 func (b *buffer) Conditions(conditions []whereCondition) {
 	for i := range conditions {
 		if i > 0 {
@@ -164,6 +330,7 @@ func (b *buffer) ValueUpdater(vps []valueUpdater) {
 		}
 		vps[i].write(b)
 	}
+	checkJSONSets(b, vps)
 }
 
 func (b *buffer) OrderSpecs(orderSpecs []*OrderSpec) {
@@ -171,10 +338,53 @@ func (b *buffer) OrderSpecs(orderSpecs []*OrderSpec) {
 		if i > 0 {
 			b.Comma()
 		}
-		b.AnyField(spec.Field)
+		b.Operand(spec.Field)
 		if spec.OrderDirection != "" {
 			b.Space()
 			b.WriteString(string(spec.OrderDirection))
 		}
 	}
 }
+
+// Row writes a row of VALUES, a placeholder for each value except the columns and
+// the expressions, e.g. Default or Raw, which are written as is. It returns the
+// args of the row.
+func (b *buffer) Row(values []any) []any {
+	if !hasExpression(values) {
+		b.WriteString(QuestionMarks(len(values)))
+		return values
+	}
+	b.OpenParen()
+	for i, v := range values {
+		if i > 0 {
+			b.Comma()
+		}
+		b.Value(v)
+	}
+	b.CloseParen()
+	return valuesArgs(b.dialect, values)
+}
+
+// hasExpression reports whether some values are columns or expressions, which
+// are not written as placeholders.
+func hasExpression(values []any) bool {
+	for _, v := range values {
+		switch v.(type) {
+		case *Field, expression:
+			return true
+		}
+	}
+	return false
+}
+
+// StringLiteral writes s as a quoted SQL string, for the places which do not
+// accept a placeholder such as the SEPARATOR of GROUP_CONCAT.
+func (b *buffer) StringLiteral(s string) {
+	s = strings.ReplaceAll(s, "'", "''")
+	if b.dialect.isMySQL() {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+	}
+	b.WriteByte('\'')
+	b.WriteString(s)
+	b.WriteByte('\'')
+}
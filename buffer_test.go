@@ -1,6 +1,7 @@
 package sqlbuilder
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -299,6 +300,15 @@ func Test_buffer_ValueUpdater(t *testing.T) {
 			},
 			want: "`field`=?,`field2`=?",
 		},
+		{
+			name: "",
+			args: args{
+				vps: []valueUpdater{
+					Set(F("a", "field"), F("b", "field")),
+				},
+			},
+			want: "`a`.`field`=`b`.`field`",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -312,3 +322,46 @@ func Test_buffer_ValueUpdater(t *testing.T) {
 		})
 	}
 }
+
+func Test_buffer_Row(t *testing.T) {
+	type args struct {
+		values []any
+	}
+	tests := []struct {
+		name     string
+		args     args
+		want     string
+		wantArgs []any
+	}{
+		{
+			name: "args",
+			args: args{
+				values: []any{1, "a"},
+			},
+			want:     "(?,?)",
+			wantArgs: []any{1, "a"},
+		},
+		{
+			name: "expressions",
+			args: args{
+				values: []any{Default, 1, Raw("NOW()"), Raw("POINT(?,?)", 2, 3)},
+			},
+			want:     "(DEFAULT,?,NOW(),POINT(?,?))",
+			wantArgs: []any{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := getBuffer()
+			args := b.Row(tt.args.values)
+			got := b.String()
+			if got != tt.want {
+				t.Errorf("Row got=%v, want=%v", got, tt.want)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("Row args got=%v, want=%v", args, tt.wantArgs)
+			}
+			releaseBuffer(b)
+		})
+	}
+}
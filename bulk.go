@@ -0,0 +1,159 @@
+package sqlbuilder
+
+import "fmt"
+
+// Statement is one of the statements built by BulkChunks.
+type Statement struct {
+	Sql  string
+	Args []any
+}
+
+// ChunkLimit bounds each statement built by BulkChunks, a zero field means no limit.
+type ChunkLimit struct {
+	// MaxRows is the number of rows inserted by a statement.
+	MaxRows int
+	// MaxPlaceholders is the number of placeholders of a statement,
+	// MySQL accepts at most 65535.
+	MaxPlaceholders int
+	// MaxBytes is the length of the SQL plus the length of the string and []byte
+	// args of a statement, e.g. to stay below max_allowed_packet.
+	MaxBytes int
+}
+
+type insertBuilderChunks SqlBuilder
+
+type insertBuilderChunksDup SqlBuilder
+
+// bulkChunks records the rows of BulkChunks, which are only split into statements
+// once the clauses following them are known. mark and argMark are the end of the
+// statement before the VALUES list, each row is kept as its rendered SQL and args.
+type bulkChunks struct {
+	mark    int
+	argMark int
+	rows    []Statement
+	limit   ChunkLimit
+}
+
+// BulkChunks is Bulk splitting the rows into as many statements as needed to stay
+// within limit. The clauses following the rows, e.g. OnDuplicate, are repeated in
+// every statement.
+func (b *insertBuilderFields) BulkChunks(n int, argf func(index int) []any, limit ChunkLimit) *insertBuilderChunks {
+	rows := make([]Statement, n)
+	buf := getBuffer()
+	buf.dialect = b.dialect
+	for i := 0; i < n; i++ {
+		row := argf(i)
+		if len(row) != len(b.columns) {
+			b.buf.setErr(fmt.Errorf("%w: row %d has %d values for %d columns", ErrColumnCount, i, len(row), len(b.columns)))
+		}
+		args := buf.Row(row)
+		rows[i] = Statement{Sql: buf.String(), Args: args}
+		buf.Reset()
+	}
+	b.buf.setErr(buf.err)
+	releaseBuffer(buf)
+	b.chunks = &bulkChunks{
+		mark:    b.buf.Len(),
+		argMark: len(b.args),
+		rows:    rows,
+		limit:   limit,
+	}
+	return (*insertBuilderChunks)(b)
+}
+
+// OnDuplicateUpdateAll is insertBuilderValues.OnDuplicateUpdateAll repeated in every statement.
+func (b *insertBuilderChunks) OnDuplicateUpdateAll(keys ...string) *insertBuilderChunksDup {
+	(*insertBuilderValues)(b).OnDuplicateUpdateAll(keys...)
+	return (*insertBuilderChunksDup)(b)
+}
+
+// OnDuplicate is insertBuilderValues.OnDuplicate repeated in every statement.
+func (b *insertBuilderChunks) OnDuplicate(vps ...valueUpdater) *insertBuilderChunksDup {
+	(*insertBuilderValues)(b).OnDuplicate(vps...)
+	return (*insertBuilderChunksDup)(b)
+}
+
+func (b *insertBuilderChunks) Build() []Statement {
+	return (*SqlBuilder)(b).buildChunks()
+}
+
+func (b *insertBuilderChunksDup) Build() []Statement {
+	return (*SqlBuilder)(b).buildChunks()
+}
+
+func (b *SqlBuilder) buildChunks() []Statement {
+	c := b.chunks
+	suffix, suffixArgs := b.cut(c.mark, c.argMark)
+	prefix := b.buf.String()
+	prefixArgs := b.args
+	if b.buf.err == nil && len(c.rows) == 0 {
+		b.buf.setErr(ErrNoRows)
+	}
+	if b.buf.err != nil {
+		b.err = b.buf.err
+		releaseBuffer(b.buf)
+		return nil
+	}
+	releaseBuffer(b.buf)
+
+	const values = " VALUES "
+	fixedBytes := len(prefix) + len(values) + len(suffix) + argsBytes(prefixArgs) + argsBytes(suffixArgs)
+	fixedArgs := len(prefixArgs) + len(suffixArgs)
+
+	var statements []Statement
+	start, size, placeholders := 0, fixedBytes, fixedArgs
+	flush := func(end int) {
+		buf := getBuffer()
+		buf.WriteString(prefix)
+		buf.WriteString(values)
+		args := make([]any, 0, placeholders)
+		args = append(args, prefixArgs...)
+		for i := start; i < end; i++ {
+			if i > start {
+				buf.Comma()
+			}
+			buf.WriteString(c.rows[i].Sql)
+			args = append(args, c.rows[i].Args...)
+		}
+		buf.WriteString(suffix)
+		args = append(args, suffixArgs...)
+		statements = append(statements, Statement{Sql: buf.String(), Args: args})
+		releaseBuffer(buf)
+	}
+	for i, row := range c.rows {
+		rowBytes := len(row.Sql) + 1 + argsBytes(row.Args)
+		if i > start && c.limit.exceeded(i-start+1, placeholders+len(row.Args), size+rowBytes) {
+			flush(i)
+			start, size, placeholders = i, fixedBytes, fixedArgs
+		}
+		if c.limit.exceeded(1, placeholders+len(row.Args), size+rowBytes) {
+			b.err = fmt.Errorf("%w: row %d alone takes %d placeholders and %d bytes, the limit is %d placeholders and %d bytes",
+				ErrChunkLimit, i, placeholders+len(row.Args), size+rowBytes, c.limit.MaxPlaceholders, c.limit.MaxBytes)
+			return nil
+		}
+		size += rowBytes
+		placeholders += len(row.Args)
+	}
+	flush(len(c.rows))
+	return statements
+}
+
+func (l ChunkLimit) exceeded(rows, placeholders, bytes int) bool {
+	return (l.MaxRows > 0 && rows > l.MaxRows) ||
+		(l.MaxPlaceholders > 0 && placeholders > l.MaxPlaceholders) ||
+		(l.MaxBytes > 0 && bytes > l.MaxBytes)
+}
+
+// argsBytes estimates the size the args add to a statement.
+func argsBytes(args []any) int {
+	n := 0
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case string:
+			n += len(v)
+		case []byte:
+			n += len(v)
+		}
+	}
+	return n
+}
@@ -0,0 +1,94 @@
+package sqlbuilder
+
+// Case starts a CASE expression. Without value it is a searched CASE whose
+// branches are conditions:
+//
+//	Case().When(Eq("status", 1), "active").Else("inactive")
+//
+// with a value it is a simple CASE comparing the value to each branch:
+//
+//	Case("status").When(1, "active").When(2, "banned")
+//
+// A result is an arg, a *Field referring to a column or an expression such as Raw.
+func Case(value ...any) *CaseExpr {
+	c := &CaseExpr{}
+	if len(value) > 0 {
+		c.Value = value[0]
+	}
+	return c
+}
+
+type CaseWhen struct {
+	When any
+	Then any
+}
+
+type CaseExpr struct {
+	_expression
+	Value any
+	Whens []*CaseWhen
+	// Otherwise is the result when no branch matches, NULL if it is nil.
+	Otherwise any
+	Alias     string
+}
+
+func (c *CaseExpr) When(when any, then any) *CaseExpr {
+	c.Whens = append(c.Whens, &CaseWhen{When: when, Then: then})
+	return c
+}
+
+func (c *CaseExpr) Else(v any) *CaseExpr {
+	c.Otherwise = v
+	return c
+}
+
+// As names the expression when it is selected.
+func (c *CaseExpr) As(alias string) *CaseExpr {
+	c.Alias = alias
+	return c
+}
+
+func (c *CaseExpr) write(buf *buffer) {
+	if len(c.Whens) == 0 {
+		buf.setErr(ErrNoWhen)
+	}
+	buf.WriteString("CASE")
+	if c.Value != nil {
+		buf.Space()
+		buf.Operand(c.Value)
+	}
+	for _, w := range c.Whens {
+		buf.WriteString(" WHEN ")
+		if cond, ok := w.When.(whereCondition); ok {
+			cond.write(buf)
+		} else {
+			buf.Value(w.When)
+		}
+		buf.WriteString(" THEN ")
+		buf.Value(w.Then)
+	}
+	if c.Otherwise != nil {
+		buf.WriteString(" ELSE ")
+		buf.Value(c.Otherwise)
+	}
+	buf.WriteString(" END")
+}
+
+func (c *CaseExpr) args(d Dialect) []any {
+	var args []any
+	if c.Value != nil {
+		args = append(args, operandArgs(d, c.Value)...)
+	}
+	for _, w := range c.Whens {
+		if cond, ok := w.When.(whereCondition); ok {
+			args = append(args, cond.args(d)...)
+		} else {
+			args = append(args, valueArgs(d, w.When)...)
+		}
+		args = append(args, valueArgs(d, w.Then)...)
+	}
+	if c.Otherwise != nil {
+		args = append(args, valueArgs(d, c.Otherwise)...)
+	}
+	return args
+}
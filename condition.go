@@ -1,12 +1,17 @@
 package sqlbuilder
 
+import (
+	"fmt"
+	"reflect"
+)
+
 type _whereCondition interface {
 	whereCondition()
 }
 
 type whereCondition interface {
 	_whereCondition
-	args() []any
+	args(d Dialect) []any
 	write(*buffer)
 }
 
@@ -24,59 +29,118 @@ func Or(conditions ...whereCondition) *BoolCondition {
 	}
 }
 
-func Lt(field any, arg any) *BinaryCondition {
+// compare returns the comparison of field with arg, a tuple comparison is
+// expanded once for the dialects without row values.
+func compare(field any, op ConditionOperator, arg any) *BinaryCondition {
+	args := []any{arg}
 	return &BinaryCondition{
 		Field: field,
-		Op:    LtOperator,
-		Args:  []any{arg},
+		Op:    op,
+		Args:  args,
+		tuple: newTupleExpansion(field, op, args),
 	}
 }
 
+func Lt(field any, arg any) *BinaryCondition {
+	return compare(field, LtOperator, arg)
+}
+
 func Eq(field any, arg any) *BinaryCondition {
+	return compare(field, EqOperator, arg)
+}
+
+func Le(field any, arg any) *BinaryCondition {
+	return compare(field, LeOperator, arg)
+}
+
+func Ge(field any, arg any) *BinaryCondition {
+	return compare(field, GeOperator, arg)
+}
+
+func Gt(field any, arg any) *BinaryCondition {
+	return compare(field, GtOperator, arg)
+}
+
+func Ne(field any, arg any) *BinaryCondition {
+	return compare(field, NeOperator, arg)
+}
+
+func Between(field any, ge any, le any) *BinaryCondition {
 	return &BinaryCondition{
 		Field: field,
-		Op:    EqOperator,
-		Args:  []any{arg},
+		Op:    BetweenOperator,
+		Args:  []any{ge, le},
 	}
 }
 
-func Le(field any, arg any) *BinaryCondition {
+// NotBetween matches the values outside [ge, le].
+func NotBetween(field any, ge any, le any) *BinaryCondition {
 	return &BinaryCondition{
 		Field: field,
-		Op:    LeOperator,
+		Op:    NotBetweenOperator,
+		Args:  []any{ge, le},
+	}
+}
+
+func NotLike(field any, arg any) *BinaryCondition {
+	return &BinaryCondition{
+		Field: field,
+		Op:    NotLikeOperator,
 		Args:  []any{arg},
 	}
 }
 
-func Ge(field any, arg any) *BinaryCondition {
+// ILike is a case-insensitive LIKE, rendered as `LOWER(field) LIKE LOWER(?)` except on PostgreSQL.
+func ILike(field any, arg any) *BinaryCondition {
 	return &BinaryCondition{
 		Field: field,
-		Op:    GeOperator,
+		Op:    ILikeOperator,
 		Args:  []any{arg},
 	}
 }
 
-func Gt(field any, arg any) *BinaryCondition {
+func NotILike(field any, arg any) *BinaryCondition {
 	return &BinaryCondition{
 		Field: field,
-		Op:    GtOperator,
+		Op:    NotILikeOperator,
 		Args:  []any{arg},
 	}
 }
 
-func Ne(field any, arg any) *BinaryCondition {
+// Regexp matches a regular expression, which is REGEXP (or its synonym RLIKE) on
+// MySQL, `~` on PostgreSQL and REGEXP_LIKE on Oracle. SQL Server does not support it.
+func Regexp(field any, pattern any) *BinaryCondition {
+	return &BinaryCondition{
+		Field: field,
+		Op:    RegexpOperator,
+		Args:  []any{pattern},
+	}
+}
+
+func NotRegexp(field any, pattern any) *BinaryCondition {
+	return &BinaryCondition{
+		Field: field,
+		Op:    NotRegexpOperator,
+		Args:  []any{pattern},
+	}
+}
+
+// NullSafeEq is an equality treating NULL as a value, `<=>` on MySQL,
+// `IS` on SQLite and `IS NOT DISTINCT FROM` elsewhere.
+func NullSafeEq(field any, arg any) *BinaryCondition {
 	return &BinaryCondition{
 		Field: field,
-		Op:    NeOperator,
+		Op:    NullSafeEqOperator,
 		Args:  []any{arg},
 	}
 }
 
-func Between(field any, ge any, le any) *BinaryCondition {
+// DistinctFrom is the negation of NullSafeEq.
+func DistinctFrom(field any, arg any) *BinaryCondition {
 	return &BinaryCondition{
 		Field: field,
-		Op:    BetweenOperator,
-		Args:  []any{ge, le},
+		Op:    DistinctFromOperator,
+		Args:  []any{arg},
 	}
 }
 
@@ -102,11 +166,27 @@ func NotNull(field any) *UnaryCondition {
 	}
 }
 
+// IsTrue is rendered as `field = 1` on SQL Server and Oracle, which have no boolean type.
+func IsTrue(field any) *UnaryCondition {
+	return &UnaryCondition{
+		Field: field,
+		Op:    IsTrueOperator,
+	}
+}
+
+func IsFalse(field any) *UnaryCondition {
+	return &UnaryCondition{
+		Field: field,
+		Op:    IsFalseOperator,
+	}
+}
+
 func In(field any, args ...any) *InCondition {
 	return &InCondition{
 		Field: field,
 		Op:    InOperator,
 		Args:  args,
+		tuple: newTupleExpansion(field, InOperator, args),
 	}
 }
 
@@ -115,6 +195,7 @@ func NotIn(field any, args ...any) *InCondition {
 		Field: field,
 		Op:    NotInOperator,
 		Args:  args,
+		tuple: newTupleExpansion(field, NotInOperator, args),
 	}
 }
 
@@ -138,26 +219,119 @@ func Condition(expr string, args ...any) *AnyCondition {
 	return &AnyCondition{Expr: expr, Args: args}
 }
 
+// EqMap compares each column named by a key of m to its value in ascending key
+// order, e.g.
+//
+//	EqMap(map[string]any{"status": 1, "type": []int{1, 2}, "deleted_at": nil})
+//
+// is rendered as `deleted_at` IS NULL AND `status` = ? AND `type` IN (?,?). An
+// empty map reports ErrEmptyFilter rather than matching every row.
+func EqMap(m map[string]any) *BoolCondition {
+	if len(m) == 0 {
+		return And(&errCondition{err: fmt.Errorf("%w: empty map", ErrEmptyFilter)})
+	}
+	keys := sortedKeys(m)
+	conditions := make([]whereCondition, len(keys))
+	for i, k := range keys {
+		conditions[i] = eqValue(F(k), m[k])
+	}
+	return And(conditions...)
+}
+
+// EqStruct compares the columns of the fields of the struct v tagged with
+// `db:"column"` to their values in declaration order, like EqMap. Zero fields
+// are skipped, so that a filter only holds the fields which are set. A struct
+// without set field reports ErrEmptyFilter rather than matching every row.
+func EqStruct(v any, opts ...StructOption) *BoolCondition {
+	columns, values, err := structColumns(v, append([]StructOption{OmitZero()}, opts...))
+	if err != nil {
+		return And(&errCondition{err: err})
+	}
+	if len(columns) == 0 {
+		return And(&errCondition{err: fmt.Errorf("%w: no field of %T is set", ErrEmptyFilter, v)})
+	}
+	conditions := make([]whereCondition, len(columns))
+	for i := range columns {
+		conditions[i] = eqValue(F(columns[i]), values[i])
+	}
+	return And(conditions...)
+}
+
+// eqValue compares field to v with IS NULL if v is nil, with IN if it is a
+// slice or an array and with = otherwise. An empty slice matches nothing.
+func eqValue(field any, v any) whereCondition {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return IsNull(field)
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return IsNull(field)
+		}
+	case reflect.Slice, reflect.Array:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			// []byte is a single value.
+			break
+		}
+		if rv.Len() == 0 {
+			return falseCondition
+		}
+		args := make([]any, rv.Len())
+		for i := range args {
+			args[i] = rv.Index(i).Interface()
+		}
+		return In(field, args...)
+	}
+	return Eq(field, v)
+}
+
+// errCondition reports an error found when the condition was constructed.
+type errCondition struct {
+	_whereCondition
+	err error
+}
+
+func (c *errCondition) write(buf *buffer) {
+	buf.setErr(c.err)
+}
+
+func (c *errCondition) args(d Dialect) []any {
+	return nil
+}
+
 type BoolCondition struct {
 	_whereCondition
 	Op         ConditionOperator
 	Conditions []whereCondition
+	// simplified is set on the groups made by simplify, which are written as is.
+	simplified bool
 }
 
-func (c *BoolCondition) args() []any {
+func (c *BoolCondition) args(d Dialect) []any {
+	s := simplify(c)
+	g, ok := s.(*BoolCondition)
+	if !ok {
+		return s.args(d)
+	}
 	list := make([]any, 0)
-	for _, cd := range c.Conditions {
-		list = append(list, cd.args()...)
+	for _, cd := range g.Conditions {
+		list = append(list, cd.args(d)...)
 	}
 	return list
 }
 
 func (c *BoolCondition) write(buf *buffer) {
+	s := simplify(c)
+	g, ok := s.(*BoolCondition)
+	if !ok {
+		s.write(buf)
+		return
+	}
 	buf.OpenParen()
-	for i, cd := range c.Conditions {
+	for i, cd := range g.Conditions {
 		if i > 0 {
 			buf.Space()
-			buf.WriteString(string(c.Op))
+			buf.WriteString(string(g.Op))
 			buf.Space()
 		}
 		cd.write(buf)
@@ -165,6 +339,173 @@ func (c *BoolCondition) write(buf *buffer) {
 	buf.CloseParen()
 }
 
+// normalize flattens the nested groups joined by the same operator and drops the
+// empty groups and the conditions which do not change the result. A group of a
+// single condition is that condition, nil is returned if the group is empty. The
+// parentheses of a group holding a raw condition are kept, its SQL may hold an
+// operator of lower precedence.
+func (c *BoolCondition) normalize() whereCondition {
+	identity, absorbing := trueCondition, falseCondition
+	if c.Op == OrOperator {
+		identity, absorbing = falseCondition, trueCondition
+	}
+	conditions := make([]whereCondition, 0, len(c.Conditions))
+	dropped := false
+	for _, cd := range c.Conditions {
+		cd = normalize(cd)
+		switch {
+		case cd == nil:
+			continue
+		case cd == identity:
+			dropped = true
+			continue
+		case cd == absorbing:
+			return absorbing
+		}
+		if g, ok := cd.(*BoolCondition); ok && g.Op == c.Op && !hasRaw(g.Conditions) {
+			conditions = append(conditions, g.Conditions...)
+			continue
+		}
+		conditions = append(conditions, cd)
+	}
+	switch len(conditions) {
+	case 0:
+		if dropped {
+			return identity
+		}
+		return nil
+	case 1:
+		if !hasRaw(conditions) {
+			return conditions[0]
+		}
+	}
+	return &BoolCondition{Op: c.Op, Conditions: conditions, simplified: true}
+}
+
+// hasRaw reports whether some conditions are written as given, see Condition.
+func hasRaw(conditions []whereCondition) bool {
+	for _, cd := range conditions {
+		if _, ok := cd.(*AnyCondition); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// simplify returns the condition to write in place of c, see normalize. An empty
+// group left at the top is true for And and false for Or.
+func simplify(c whereCondition) whereCondition {
+	if s := normalize(c); s != nil {
+		return s
+	}
+	switch v := c.(type) {
+	case *NotCondition:
+		return negate(simplify(v.Condition))
+	case *BoolCondition:
+		if v.Op == OrOperator {
+			return falseCondition
+		}
+	}
+	return trueCondition
+}
+
+func normalize(c whereCondition) whereCondition {
+	switch v := c.(type) {
+	case *BoolCondition:
+		if v.simplified {
+			return v
+		}
+		return v.normalize()
+	case *NotCondition:
+		if v.simplified {
+			return v
+		}
+		return v.normalize()
+	}
+	return c
+}
+
+// Not negates the condition, e.g. Not(Or(a, b)) is rendered as `NOT (a OR b)`.
+func Not(condition whereCondition) *NotCondition {
+	return &NotCondition{Condition: condition}
+}
+
+type NotCondition struct {
+	_whereCondition
+	Condition  whereCondition
+	simplified bool
+}
+
+func (c *NotCondition) normalize() whereCondition {
+	cd := normalize(c.Condition)
+	if cd == nil {
+		return nil
+	}
+	return negate(cd)
+}
+
+// negate returns the negation of the simplified condition c.
+func negate(c whereCondition) whereCondition {
+	switch c {
+	case trueCondition:
+		return falseCondition
+	case falseCondition:
+		return trueCondition
+	}
+	if n, ok := c.(*NotCondition); ok {
+		return n.Condition
+	}
+	return &NotCondition{Condition: c, simplified: true}
+}
+
+func (c *NotCondition) write(buf *buffer) {
+	s := simplify(c)
+	n, ok := s.(*NotCondition)
+	if !ok {
+		s.write(buf)
+		return
+	}
+	buf.WriteString("NOT ")
+	if _, ok := n.Condition.(*BoolCondition); ok {
+		n.Condition.write(buf)
+		return
+	}
+	buf.OpenParen()
+	n.Condition.write(buf)
+	buf.CloseParen()
+}
+
+func (c *NotCondition) args(d Dialect) []any {
+	s := simplify(c)
+	if n, ok := s.(*NotCondition); ok {
+		return n.Condition.args(d)
+	}
+	return s.args(d)
+}
+
+var (
+	trueCondition  = &constCondition{value: true}
+	falseCondition = &constCondition{value: false}
+)
+
+// constCondition is the result of a simplified condition known in advance.
+type constCondition struct {
+	_whereCondition
+	value bool
+}
+
+func (c *constCondition) write(buf *buffer) {
+	if c.value {
+		buf.WriteString("1=1")
+	} else {
+		buf.WriteString("1=0")
+	}
+}
+
+func (c *constCondition) args(d Dialect) []any {
+	return nil
+}
+
 type UnaryCondition struct {
 	_whereCondition
 	Field any
@@ -172,13 +513,21 @@ type UnaryCondition struct {
 }
 
 func (c *UnaryCondition) write(buf *buffer) {
-	buf.AnyField(c.Field)
+	buf.Operand(c.Field)
+	if (c.Op == IsTrueOperator || c.Op == IsFalseOperator) && (buf.dialect == SQLServer || buf.dialect == Oracle) {
+		if c.Op == IsTrueOperator {
+			buf.WriteString(" = 1")
+		} else {
+			buf.WriteString(" = 0")
+		}
+		return
+	}
 	buf.Space()
 	buf.WriteString(string(c.Op))
 }
 
-func (c *UnaryCondition) args() []any {
-	return nil
+func (c *UnaryCondition) args(d Dialect) []any {
+	return withOperand(d, c.Field, nil)
 }
 
 type BinaryCondition struct {
@@ -186,31 +535,142 @@ type BinaryCondition struct {
 	Field any
 	Op    ConditionOperator
 	Args  []any
+	tuple *tupleExpansion
 }
 
 func (c *BinaryCondition) write(buf *buffer) {
+	e, err := c.tuple.resolve(buf.dialect, c.Field, c.Op, c.Args)
+	if err != nil {
+		buf.setErr(err)
+	} else if e != nil {
+		e.write(buf)
+		return
+	}
 	switch c.Op {
-	case BetweenOperator:
-		buf.AnyField(c.Field)
+	case BetweenOperator, NotBetweenOperator:
+		buf.Operand(c.Field)
 		buf.Space()
-		buf.WriteString(string(BetweenOperator))
+		buf.WriteString(string(c.Op))
 		buf.Space()
-		buf.Question()
+		c.writeArg(buf, 0)
 		buf.Space()
 		buf.WriteString(string(AndOperator))
 		buf.Space()
-		buf.Question()
+		c.writeArg(buf, 1)
+	case ILikeOperator, NotILikeOperator:
+		c.writeILike(buf)
+	case RegexpOperator, NotRegexpOperator:
+		c.writeRegexp(buf)
+	case NullSafeEqOperator, DistinctFromOperator:
+		c.writeNullSafe(buf)
 	default:
-		buf.AnyField(c.Field)
-		buf.Space()
-		buf.WriteString(string(c.Op))
-		buf.Space()
-		buf.Question()
+		c.writeOp(buf, string(c.Op))
 	}
 }
 
-func (c *BinaryCondition) args() []any {
-	return c.Args
+func (c *BinaryCondition) writeOp(buf *buffer, op string) {
+	buf.Operand(c.Field)
+	buf.Space()
+	buf.WriteString(op)
+	buf.Space()
+	c.writeArg(buf, 0)
+}
+
+// writeArg writes the i-th arg, a placeholder unless it is a column or an expression.
+func (c *BinaryCondition) writeArg(buf *buffer, i int) {
+	if i < len(c.Args) {
+		buf.Value(c.Args[i])
+		return
+	}
+	buf.Question()
+}
+
+func (c *BinaryCondition) writeILike(buf *buffer) {
+	if buf.dialect == PostgreSQL {
+		c.writeOp(buf, string(c.Op))
+		return
+	}
+	op := LikeOperator
+	if c.Op == NotILikeOperator {
+		op = NotLikeOperator
+	}
+	buf.WriteString("LOWER(")
+	buf.Operand(c.Field)
+	buf.WriteString(") ")
+	buf.WriteString(string(op))
+	buf.WriteString(" LOWER(")
+	c.writeArg(buf, 0)
+	buf.CloseParen()
+}
+
+func (c *BinaryCondition) writeRegexp(buf *buffer) {
+	not := c.Op == NotRegexpOperator
+	switch buf.dialect {
+	case PostgreSQL:
+		if not {
+			c.writeOp(buf, "!~")
+		} else {
+			c.writeOp(buf, "~")
+		}
+	case Oracle:
+		if not {
+			buf.WriteString("NOT ")
+		}
+		buf.WriteString("REGEXP_LIKE(")
+		buf.Operand(c.Field)
+		buf.Comma()
+		c.writeArg(buf, 0)
+		buf.CloseParen()
+	case SQLServer:
+		buf.setErr(unsupported(buf.dialect, string(c.Op)))
+		c.writeOp(buf, string(c.Op))
+	default:
+		c.writeOp(buf, string(c.Op))
+	}
+}
+
+func (c *BinaryCondition) writeNullSafe(buf *buffer) {
+	distinct := c.Op == DistinctFromOperator
+	switch buf.dialect {
+	case MySQL, MariaDB:
+		if distinct {
+			buf.WriteString("NOT (")
+			c.writeOp(buf, string(NullSafeEqOperator))
+			buf.CloseParen()
+		} else {
+			c.writeOp(buf, string(NullSafeEqOperator))
+		}
+	case SQLite:
+		if distinct {
+			c.writeOp(buf, "IS NOT")
+		} else {
+			c.writeOp(buf, "IS")
+		}
+	case Oracle:
+		// DECODE considers two NULLs equal.
+		buf.WriteString("DECODE(")
+		buf.Operand(c.Field)
+		buf.Comma()
+		c.writeArg(buf, 0)
+		if distinct {
+			buf.WriteString(",0,1) = 1")
+		} else {
+			buf.WriteString(",1,0) = 1")
+		}
+	default:
+		if distinct {
+			c.writeOp(buf, string(DistinctFromOperator))
+		} else {
+			c.writeOp(buf, "IS NOT DISTINCT FROM")
+		}
+	}
+}
+
+func (c *BinaryCondition) args(d Dialect) []any {
+	if e, _ := c.tuple.resolve(d, c.Field, c.Op, c.Args); e != nil {
+		return e.args(d)
+	}
+	return withOperand(d, c.Field, valuesArgs(d, c.Args))
 }
 
 type InCondition struct {
@@ -218,18 +678,29 @@ type InCondition struct {
 	Field any
 	Op    ConditionOperator
 	Args  []any
+	tuple *tupleExpansion
 }
 
 func (c *InCondition) write(buf *buffer) {
-	buf.AnyField(c.Field)
+	e, err := c.tuple.resolve(buf.dialect, c.Field, c.Op, c.Args)
+	if err != nil {
+		buf.setErr(err)
+	} else if e != nil {
+		e.write(buf)
+		return
+	}
+	buf.Operand(c.Field)
 	buf.Space()
 	buf.WriteString(string(c.Op))
 	buf.Space()
-	buf.WriteString(QuestionMarks(len(c.Args)))
+	buf.Row(c.Args)
 }
 
-func (c *InCondition) args() []any {
-	return c.Args
+func (c *InCondition) args(d Dialect) []any {
+	if e, _ := c.tuple.resolve(d, c.Field, c.Op, c.Args); e != nil {
+		return e.args(d)
+	}
+	return withOperand(d, c.Field, valuesArgs(d, c.Args))
 }
 
 type SubqueryCondition struct {
@@ -242,7 +713,7 @@ type SubqueryCondition struct {
 
 func (c *SubqueryCondition) write(buf *buffer) {
 	if c.Field != nil {
-		buf.AnyField(c.Field)
+		buf.Operand(c.Field)
 		buf.Space()
 	}
 	buf.WriteString(string(c.Op))
@@ -252,8 +723,8 @@ func (c *SubqueryCondition) write(buf *buffer) {
 	buf.CloseParen()
 }
 
-func (c *SubqueryCondition) args() []any {
-	return c.Args
+func (c *SubqueryCondition) args(d Dialect) []any {
+	return withOperand(d, c.Field, c.Args)
 }
 
 type AnyCondition struct {
@@ -262,7 +733,7 @@ type AnyCondition struct {
 	Args []any
 }
 
-func (c *AnyCondition) args() []any {
+func (c *AnyCondition) args(d Dialect) []any {
 	return c.Args
 }
 
@@ -1,6 +1,7 @@
 package sqlbuilder
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -37,6 +38,17 @@ func TestCondition_multiCondition(t *testing.T) {
 			},
 			want: "`name` IN (?,?) AND `name` NOT IN (?) AND EXISTS (select 1) AND NOT EXISTS (select 2)",
 		},
+		{
+			name: "",
+			conditions: []whereCondition{
+				Eq(F("a", "x"), F("b", "y")),
+				Le(F("c"), Col("d")),
+				Between(F("e"), F("lo"), Col("t", "hi")),
+				Gt(F("f"), Raw("NOW()")),
+				In(F("g"), F("h"), 1),
+			},
+			want: "`a`.`x` = `b`.`y` AND `c` <= `d` AND `e` BETWEEN `lo` AND `t`.`hi` AND `f` > NOW() AND `g` IN (`h`,?)",
+		},
 		{
 			name: "",
 			conditions: []whereCondition{
@@ -55,6 +67,22 @@ func TestCondition_multiCondition(t *testing.T) {
 			},
 			want: "(`age` >= ? AND (`name` = ? OR `name` = ?)) AND `name` IN (?) AND (`name` = ? OR `age` > ?)",
 		},
+		{
+			name: "not and normalization",
+			conditions: []whereCondition{
+				Not(Eq(F("a"), 1)),
+				Not(Or(Eq(F("b"), 2), Eq(F("c"), 3))),
+				Not(Not(IsNull(F("d")))),
+				And(Eq(F("e"), 4), And(Eq(F("f"), 5), And()), Or(Eq(F("g"), 6))),
+				Or(Eq(F("h"), 7), Or(Eq(F("i"), 8), Or()), And()),
+				And(),
+				Or(),
+				Not(Or()),
+				Or(Condition("x=1 OR y=2")),
+				And(Eq(F("j"), 9), And(Condition("k=1 OR l=2"))),
+			},
+			want: "NOT (`a` = ?) AND NOT (`b` = ? OR `c` = ?) AND `d` IS NULL AND (`e` = ? AND `f` = ? AND `g` = ?) AND (`h` = ? OR `i` = ?) AND 1=1 AND 1=0 AND 1=1 AND (x=1 OR y=2) AND (`j` = ? AND (k=1 OR l=2))",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -67,3 +95,74 @@ func TestCondition_multiCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestCondition_dialect(t *testing.T) {
+	conditions := []whereCondition{
+		NotLike(F("a"), "x%"),
+		ILike(F("b"), "y%"),
+		Regexp(F("c"), "^z"),
+		NotRegexp(F("c"), "^w"),
+		NullSafeEq(F("d"), nil),
+		DistinctFrom(F("e"), 1),
+		NotBetween(F("f"), 1, 9),
+		IsTrue(F("g")),
+		IsFalse(F("h")),
+	}
+	tests := []struct {
+		name    string
+		dialect Dialect
+		want    string
+	}{
+		{
+			name:    "mysql",
+			dialect: MySQL,
+			want: "`a` NOT LIKE ? AND LOWER(`b`) LIKE LOWER(?) AND `c` REGEXP ? AND `c` NOT REGEXP ? AND `d` <=> ?" +
+				" AND NOT (`e` <=> ?) AND `f` NOT BETWEEN ? AND ? AND `g` IS TRUE AND `h` IS FALSE",
+		},
+		{
+			name:    "postgresql",
+			dialect: PostgreSQL,
+			want: `"a" NOT LIKE ? AND "b" ILIKE ? AND "c" ~ ? AND "c" !~ ? AND "d" IS NOT DISTINCT FROM ?` +
+				` AND "e" IS DISTINCT FROM ? AND "f" NOT BETWEEN ? AND ? AND "g" IS TRUE AND "h" IS FALSE`,
+		},
+		{
+			name:    "sqlite",
+			dialect: SQLite,
+			want: `"a" NOT LIKE ? AND LOWER("b") LIKE LOWER(?) AND "c" REGEXP ? AND "c" NOT REGEXP ? AND "d" IS ?` +
+				` AND "e" IS NOT ? AND "f" NOT BETWEEN ? AND ? AND "g" IS TRUE AND "h" IS FALSE`,
+		},
+		{
+			name:    "oracle",
+			dialect: Oracle,
+			want: `"a" NOT LIKE ? AND LOWER("b") LIKE LOWER(?) AND REGEXP_LIKE("c",?) AND NOT REGEXP_LIKE("c",?) AND DECODE("d",?,1,0) = 1` +
+				` AND DECODE("e",?,0,1) = 1 AND "f" NOT BETWEEN ? AND ? AND "g" = 1 AND "h" = 0`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := newBuffer(1024)
+			buf.dialect = tt.dialect
+			buf.Conditions(conditions)
+			got := buf.String()
+			if got != tt.want {
+				t.Errorf("dialect got=%v, want=%v", got, tt.want)
+			}
+			if buf.err != nil {
+				t.Errorf("dialect err=%v", buf.err)
+			}
+		})
+	}
+}
+
+func TestCondition_simplifyArgs(t *testing.T) {
+	c := And(Eq(F("a"), 1), Or(Eq(F("b"), 2), And()), Not(Or(Eq(F("c"), 3), Or(Eq(F("d"), 4)))))
+	buf := newBuffer(1024)
+	buf.Conditions([]whereCondition{c})
+	want := "(`a` = ? AND `b` = ? AND NOT (`c` = ? OR `d` = ?))"
+	if got := buf.String(); got != want {
+		t.Errorf("simplify got=%v, want=%v", got, want)
+	}
+	if got := c.args(MySQL); !reflect.DeepEqual(got, []any{1, 2, 3, 4}) {
+		t.Errorf("simplify args=%v", got)
+	}
+}
@@ -7,9 +7,14 @@ const (
 	SqlCache   Keyword = "SQL_CACHE"
 	SqlNoCache Keyword = "SQL_NO_CACHE"
 
-	leftJoin  Keyword = "LEFT JOIN"
-	rightJoin Keyword = "RIGHT JOIN"
-	innerJoin Keyword = "INNER JOIN"
+	leftJoin     Keyword = "LEFT JOIN"
+	rightJoin    Keyword = "RIGHT JOIN"
+	innerJoin    Keyword = "INNER JOIN"
+	crossJoin    Keyword = "CROSS JOIN"
+	fullJoin     Keyword = "FULL OUTER JOIN"
+	naturalJoin  Keyword = "NATURAL JOIN"
+	straightJoin Keyword = "STRAIGHT_JOIN"
+	lateral      Keyword = "LATERAL"
 )
 
 type OrderDirection string
@@ -19,6 +24,25 @@ const (
 	Desc OrderDirection = "DESC"
 )
 
+// MatchMode is the search modifier of Match.
+type MatchMode string
+
+const (
+	NaturalLanguageMode MatchMode = "IN NATURAL LANGUAGE MODE"
+	BooleanMode         MatchMode = "IN BOOLEAN MODE"
+	QueryExpansion      MatchMode = "WITH QUERY EXPANSION"
+)
+
+// valid reports whether the mode is empty or one of the constants, the mode is
+// written verbatim on MySQL.
+func (m MatchMode) valid() bool {
+	switch m {
+	case "", NaturalLanguageMode, BooleanMode, QueryExpansion:
+		return true
+	}
+	return false
+}
+
 type ConditionOperator string
 
 const (
@@ -32,16 +56,28 @@ const (
 	GeOperator ConditionOperator = ">="
 	NeOperator ConditionOperator = "!="
 
-	BetweenOperator   ConditionOperator = "BETWEEN"
-	ExistsOperator    ConditionOperator = "EXISTS"
-	NotExistsOperator ConditionOperator = "NOT EXISTS"
-	LikeOperator      ConditionOperator = "LIKE"
+	BetweenOperator    ConditionOperator = "BETWEEN"
+	NotBetweenOperator ConditionOperator = "NOT BETWEEN"
+	ExistsOperator     ConditionOperator = "EXISTS"
+	NotExistsOperator  ConditionOperator = "NOT EXISTS"
+	LikeOperator       ConditionOperator = "LIKE"
+	NotLikeOperator    ConditionOperator = "NOT LIKE"
+	ILikeOperator      ConditionOperator = "ILIKE"
+	NotILikeOperator   ConditionOperator = "NOT ILIKE"
+	RegexpOperator     ConditionOperator = "REGEXP"
+	NotRegexpOperator  ConditionOperator = "NOT REGEXP"
+
+	// NullSafeEqOperator is the MySQL `<=>`, which is `IS NOT DISTINCT FROM` in standard SQL.
+	NullSafeEqOperator   ConditionOperator = "<=>"
+	DistinctFromOperator ConditionOperator = "IS DISTINCT FROM"
 
 	InOperator    ConditionOperator = "IN"
 	NotInOperator ConditionOperator = "NOT IN"
 
 	IsNullOperator  ConditionOperator = "IS NULL"
 	NotNullOperator ConditionOperator = "IS NOT NULL"
+	IsTrueOperator  ConditionOperator = "IS TRUE"
+	IsFalseOperator ConditionOperator = "IS FALSE"
 )
 
 const (
@@ -53,6 +89,7 @@ const (
 	equalMark        = '='
 	questionMark     = '?'
 	backQuote        = '`'
+	doubleQuote      = '"'
 )
 
 type Table struct {
@@ -86,9 +123,32 @@ func T(args ...string) *Table {
 	return table
 }
 
+type Subquery struct {
+	Sql   string
+	Args  []any
+	Alias string
+}
+
+// Sub wraps a built statement so that it can be used as a derived table, e.g.
+//
+//	sb.Sub(sb.New().Select().Field().From("demo").Build()).As("d")
+func Sub(sql string, args []any) *Subquery {
+	return &Subquery{
+		Sql:  sql,
+		Args: args,
+	}
+}
+
+func (s *Subquery) As(alias string) *Subquery {
+	s.Alias = alias
+	return s
+}
+
 type Expr struct {
+	_expression
 	Expr  string
 	Alias string
+	Args  []any
 }
 
 // E Specify an Expression. Different numbers of parameters will have different effects.
@@ -141,6 +201,13 @@ func F(args ...string) *Field {
 	return f
 }
 
+// Col refers to a column on the right-hand side of a condition, e.g.
+// Eq(F("a", "x"), Col("b", "y")) is rendered as `a.x = b.y`. It takes the
+// same parameters as F, a *Field given by F is compared as a column too.
+func Col(args ...string) *Field {
+	return F(args...)
+}
+
 type OrderSpec struct {
 	Field          any
 	OrderDirection OrderDirection
@@ -4,12 +4,24 @@ type deleteBuilder SqlBuilder
 
 type deleteBuilderTable SqlBuilder
 
+type deleteBuilderTargets SqlBuilder
+
+type deleteBuilderSource SqlBuilder
+
+type deleteBuilderJoin SqlBuilder
+
+type deleteBuilderJoinSpec SqlBuilder
+
+type deleteBuilderJoinWhere SqlBuilder
+
 type deleteBuilderWhere SqlBuilder
 
 type deleteBuilderOrder SqlBuilder
 
 type deleteBuilderLimit SqlBuilder
 
+type deleteBuilderReturning SqlBuilder
+
 func (b *deleteBuilder) init(kws []Keyword) *deleteBuilder {
 	b.buf.WriteString("DELETE")
 	for _, kw := range kws {
@@ -23,7 +35,8 @@ func (b *deleteBuilder) From(table string) *deleteBuilderTable {
 	b.buf.Space()
 	b.buf.WriteString("FROM")
 	b.buf.Space()
-	b.buf.BackQuoteString(table)
+	b.buf.Quote(table)
+	(*SqlBuilder)(b).markOutput()
 	return (*deleteBuilderTable)(b)
 }
 
@@ -32,9 +45,144 @@ func (b *deleteBuilder) FromT(table *Table) *deleteBuilderTable {
 	b.buf.WriteString("FROM")
 	b.buf.Space()
 	b.buf.Table(table)
+	(*SqlBuilder)(b).markOutput()
 	return (*deleteBuilderTable)(b)
 }
 
+// Targets names the tables to delete from, which are referred by their name or alias
+// in From and the joins, e.g.
+//
+//	Delete().Targets("o").FromT(T("orders", "o")).InnerJoin(T("users", "u")).On(...)
+//
+// is rendered as `DELETE o FROM orders AS o INNER JOIN ...` on MySQL and as
+// `DELETE FROM orders AS o USING users AS u WHERE ...` on PostgreSQL, where the
+// only target has to be the From table.
+func (b *deleteBuilder) Targets(tables ...string) *deleteBuilderTargets {
+	if b.dialect.isMySQL() {
+		b.buf.Space()
+		b.buf.Quotes(tables)
+	}
+	b.targets = tables
+	return (*deleteBuilderTargets)(b)
+}
+
+func (b *deleteBuilderTargets) From(table string) *deleteBuilderSource {
+	return b.FromT(T(table))
+}
+
+func (b *deleteBuilderTargets) FromT(table *Table) *deleteBuilderSource {
+	if !b.dialect.isMySQL() {
+		ref := table.Alias
+		if ref == "" {
+			ref = table.Table
+		}
+		if len(b.targets) != 1 || b.targets[0] != ref {
+			b.buf.setErr(unsupported(b.dialect, "deleting from tables other than the FROM table"))
+		}
+	}
+	b.buf.Space()
+	b.buf.WriteString("FROM")
+	b.buf.Space()
+	b.buf.Table(table)
+	b.target = table
+	return (*deleteBuilderSource)(b)
+}
+
+func (b *deleteBuilderSource) LeftJoin(table *Table) *deleteBuilderJoin {
+	return (*deleteBuilderJoin)(b).join(leftJoin, table)
+}
+
+func (b *deleteBuilderSource) RightJoin(table *Table) *deleteBuilderJoin {
+	return (*deleteBuilderJoin)(b).join(rightJoin, table)
+}
+
+func (b *deleteBuilderSource) InnerJoin(table *Table) *deleteBuilderJoin {
+	return (*deleteBuilderJoin)(b).join(innerJoin, table)
+}
+
+func (b *deleteBuilderSource) CrossJoin(table *Table) *deleteBuilderJoinSpec {
+	return (*deleteBuilderJoinSpec)(b).join(crossJoin, table)
+}
+
+func (b *deleteBuilderSource) Where(conditions ...whereCondition) *deleteBuilderJoinWhere {
+	return (*deleteBuilderJoinWhere)(b).where(conditions)
+}
+
+func (b *deleteBuilderSource) Build() (string, []any) {
+	return (*sqlBuilderBuild)(b).Build()
+}
+
+func (b *deleteBuilderJoin) join(joinType Keyword, table *Table) *deleteBuilderJoin {
+	(*SqlBuilder)(b).joinFrom("DELETE", joinType, table, "USING")
+	return b
+}
+
+func (b *deleteBuilderJoin) On(lhs, rhs *Field) *deleteBuilderJoinSpec {
+	(*SqlBuilder)(b).on(lhs, rhs)
+	return (*deleteBuilderJoinSpec)(b)
+}
+
+func (b *deleteBuilderJoin) OnCondition(conditions ...whereCondition) *deleteBuilderJoinSpec {
+	(*SqlBuilder)(b).onConditions(conditions)
+	return (*deleteBuilderJoinSpec)(b)
+}
+
+func (b *deleteBuilderJoin) Using(fields ...string) *deleteBuilderJoinSpec {
+	(*SqlBuilder)(b).using(fields)
+	return (*deleteBuilderJoinSpec)(b)
+}
+
+func (b *deleteBuilderJoinSpec) join(joinType Keyword, table *Table) *deleteBuilderJoinSpec {
+	(*SqlBuilder)(b).joinFrom("DELETE", joinType, table, "USING")
+	return b
+}
+
+func (b *deleteBuilderJoinSpec) LeftJoin(table *Table) *deleteBuilderJoin {
+	return (*deleteBuilderJoin)(b).join(leftJoin, table)
+}
+
+func (b *deleteBuilderJoinSpec) RightJoin(table *Table) *deleteBuilderJoin {
+	return (*deleteBuilderJoin)(b).join(rightJoin, table)
+}
+
+func (b *deleteBuilderJoinSpec) InnerJoin(table *Table) *deleteBuilderJoin {
+	return (*deleteBuilderJoin)(b).join(innerJoin, table)
+}
+
+func (b *deleteBuilderJoinSpec) CrossJoin(table *Table) *deleteBuilderJoinSpec {
+	return b.join(crossJoin, table)
+}
+
+func (b *deleteBuilderJoinSpec) Where(conditions ...whereCondition) *deleteBuilderJoinWhere {
+	return (*deleteBuilderJoinWhere)(b).where(conditions)
+}
+
+func (b *deleteBuilderJoinSpec) Build() (string, []any) {
+	(*SqlBuilder)(b).whereFrom()
+	return (*sqlBuilderBuild)(b).Build()
+}
+
+func (b *deleteBuilderJoinWhere) where(conditions []whereCondition) *deleteBuilderJoinWhere {
+	(*SqlBuilder)(b).whereFrom()
+	(*SqlBuilder)(b).where(conditions)
+	return b
+}
+
+func (b *deleteBuilderJoinWhere) Returning(fields ...any) *deleteBuilderReturning {
+	return (*deleteBuilderReturning)(b).returning(fields)
+}
+
+func (b *deleteBuilderJoinWhere) Build() (string, []any) {
+	return (*sqlBuilderBuild)(b).Build()
+}
+
+// Returning returns the given fields of the deleted rows, or all columns when
+// no field is given. It is rendered as `OUTPUT DELETED.field` on SQL Server and
+// is not supported by MySQL and Oracle.
+func (b *deleteBuilderTable) Returning(fields ...any) *deleteBuilderReturning {
+	return (*deleteBuilderReturning)(b).returning(fields)
+}
+
 func (b *deleteBuilderTable) Build() (string, []any) {
 	return (*sqlBuilderBuild)(b).Build()
 }
@@ -45,7 +193,7 @@ func (b *deleteBuilderTable) Where(conditions ...whereCondition) *deleteBuilderW
 	b.buf.Space()
 	b.buf.Conditions(conditions)
 	for i := range conditions {
-		b.args = append(b.args, conditions[i].args()...)
+		b.args = append(b.args, conditions[i].args(b.dialect)...)
 	}
 	return (*deleteBuilderWhere)(b)
 }
@@ -66,6 +214,10 @@ func (b *deleteBuilderWhere) Limit(limit any) *deleteBuilderLimit {
 	return (*deleteBuilderLimit)(b).limit(limit)
 }
 
+func (b *deleteBuilderWhere) Returning(fields ...any) *deleteBuilderReturning {
+	return (*deleteBuilderReturning)(b).returning(fields)
+}
+
 func (b *deleteBuilderWhere) Build() (string, []any) {
 	return (*sqlBuilderBuild)(b).Build()
 }
@@ -75,6 +227,7 @@ func (b *deleteBuilderOrder) order(orderSpecs []*OrderSpec) *deleteBuilderOrder
 	b.buf.WriteString("ORDER BY")
 	b.buf.Space()
 	b.buf.OrderSpecs(orderSpecs)
+	b.args = append(b.args, orderArgs(b.dialect, orderSpecs)...)
 	return b
 }
 
@@ -98,3 +251,12 @@ func (b *deleteBuilderLimit) limit(limit any) *deleteBuilderLimit {
 func (b *deleteBuilderLimit) Build() (string, []any) {
 	return (*sqlBuilderBuild)(b).Build()
 }
+
+func (b *deleteBuilderReturning) returning(fields []any) *deleteBuilderReturning {
+	(*SqlBuilder)(b).returning("DELETE", fields)
+	return b
+}
+
+func (b *deleteBuilderReturning) Build() (string, []any) {
+	return (*sqlBuilderBuild)(b).Build()
+}
@@ -0,0 +1,106 @@
+package sqlbuilder
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupported is reported by SqlBuilder.Err when a statement uses a construct
+// the selected dialect cannot express.
+var ErrUnsupported = errors.New("sqlbuilder: unsupported by dialect")
+
+// Dialect selects the database flavour a statement is rendered for. MySQL is the default.
+//
+// Placeholders are always rendered as `?`, rebind them if the driver expects another style.
+type Dialect int
+
+const (
+	MySQL Dialect = iota
+	MariaDB
+	PostgreSQL
+	SQLite
+	SQLServer
+	Oracle
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case MySQL:
+		return "MySQL"
+	case MariaDB:
+		return "MariaDB"
+	case PostgreSQL:
+		return "PostgreSQL"
+	case SQLite:
+		return "SQLite"
+	case SQLServer:
+		return "SQL Server"
+	case Oracle:
+		return "Oracle"
+	}
+	return fmt.Sprintf("Dialect(%d)", int(d))
+}
+
+func (d Dialect) isMySQL() bool {
+	return d == MySQL || d == MariaDB
+}
+
+func (d Dialect) quote() byte {
+	if d.isMySQL() {
+		return backQuote
+	}
+	return doubleQuote
+}
+
+func (d Dialect) supportsJoin(joinType Keyword) bool {
+	switch joinType {
+	case fullJoin:
+		return !d.isMySQL()
+	case naturalJoin:
+		return d != SQLServer
+	case straightJoin:
+		return d.isMySQL()
+	}
+	return true
+}
+
+// supportsJoinFrom reports whether the dialect can join tables in an UPDATE or DELETE.
+func (d Dialect) supportsJoinFrom(stmt string) bool {
+	switch d {
+	case MySQL, MariaDB, PostgreSQL:
+		return true
+	case SQLite:
+		return stmt == "UPDATE"
+	}
+	return false
+}
+
+func (d Dialect) supportsReturning(stmt string) bool {
+	switch d {
+	case PostgreSQL, SQLite:
+		return true
+	case MariaDB:
+		return stmt != "UPDATE"
+	}
+	return false
+}
+
+// supportsRowValues reports whether the dialect can compare row values with op,
+// e.g. `(a, b) > (?, ?)`.
+func (d Dialect) supportsRowValues(op ConditionOperator) bool {
+	switch d {
+	case SQLServer:
+		return false
+	case Oracle:
+		return op == InOperator || op == NotInOperator
+	}
+	return true
+}
+
+func (d Dialect) supportsLateral() bool {
+	return d == MySQL || d == PostgreSQL || d == Oracle
+}
+
+func unsupported(d Dialect, what string) error {
+	return fmt.Errorf("%w: %s on %s", ErrUnsupported, what, d)
+}
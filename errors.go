@@ -0,0 +1,50 @@
+package sqlbuilder
+
+import "errors"
+
+// Errors reported by SqlBuilder.Err, they are wrapped with the details of the
+// statement and are matched with errors.Is.
+var (
+	// ErrNoConflictKey is reported when an upsert does not name the unique columns
+	// identifying the conflicting row.
+	ErrNoConflictKey = errors.New("sqlbuilder: no conflict key")
+	// ErrNoRows is reported when a statement inserts no row.
+	ErrNoRows = errors.New("sqlbuilder: no row to insert")
+	// ErrChunkLimit is reported by BulkChunks when a single row does not fit in
+	// the limit of a statement.
+	ErrChunkLimit = errors.New("sqlbuilder: row exceeds the chunk limit")
+	// ErrNoColumnToUpdate is reported when an UPDATE, an upsert or a conflict
+	// clause updates no column.
+	ErrNoColumnToUpdate = errors.New("sqlbuilder: no column to update")
+	// ErrColumnCount is reported when the number of values or selected fields
+	// does not match the number of columns.
+	ErrColumnCount = errors.New("sqlbuilder: column count mismatch")
+	// ErrEmptyFilter is reported by EqMap and EqStruct when they have nothing to
+	// compare, which would match every row.
+	ErrEmptyFilter = errors.New("sqlbuilder: empty filter")
+	// ErrNoField is reported when an expression requires a field and none is given.
+	ErrNoField = errors.New("sqlbuilder: no field")
+	// ErrNoWhen is reported when a CASE expression has no WHEN branch.
+	ErrNoWhen = errors.New("sqlbuilder: CASE without WHEN")
+	// ErrNoQuery is reported when a full-text search is not given a query with Against.
+	ErrNoQuery = errors.New("sqlbuilder: no full-text query")
+	// ErrMatchMode is reported when the mode of a full-text search is not one of
+	// the MatchMode constants.
+	ErrMatchMode = errors.New("sqlbuilder: invalid match mode")
+	// ErrDuplicateColumn is reported when a column is assigned more than once,
+	// e.g. two paths of a JSON column set apart.
+	ErrDuplicateColumn = errors.New("sqlbuilder: column assigned more than once")
+	// ErrInvalidColumn is reported when a column, or a struct holding columns, is
+	// expected and something else, e.g. an expression, is given.
+	ErrInvalidColumn = errors.New("sqlbuilder: invalid column")
+	// ErrSubquery is reported when a subquery is neither SQL nor a select chain.
+	ErrSubquery = errors.New("sqlbuilder: invalid subquery")
+	// ErrSubqueryArgs is reported when args are given along with a select chain,
+	// which carries its own args.
+	ErrSubqueryArgs = errors.New("sqlbuilder: args given with a select chain")
+	// ErrDialectMismatch is reported when a statement embeds a statement built
+	// for another dialect.
+	ErrDialectMismatch = errors.New("sqlbuilder: dialect mismatch")
+	// ErrJSONPath is reported when a JSON path cannot be rendered for the dialect.
+	ErrJSONPath = errors.New("sqlbuilder: unsupported JSON path")
+)
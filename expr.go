@@ -0,0 +1,179 @@
+package sqlbuilder
+
+type _expression interface {
+	expression()
+}
+
+// expression is an SQL fragment which is rendered for the dialect at build time
+// and carries its own args. As for conditions, the args are those of the fragment
+// written for the dialect d, which may differ in number and order between dialects.
+type expression interface {
+	_expression
+	args(d Dialect) []any
+	write(*buffer)
+}
+
+// operandArgs returns the args of a field written by buffer.Operand or buffer.AnyField.
+func operandArgs(d Dialect, field any) []any {
+	if e, ok := field.(expression); ok {
+		return e.args(d)
+	}
+	return nil
+}
+
+// fieldsArgs returns the args of the fields written by buffer.AnyFields.
+func fieldsArgs(d Dialect, fields []any) []any {
+	var args []any
+	for _, f := range fields {
+		args = append(args, operandArgs(d, f)...)
+	}
+	return args
+}
+
+// orderArgs returns the args of the fields written by buffer.OrderSpecs.
+func orderArgs(d Dialect, orderSpecs []*OrderSpec) []any {
+	var args []any
+	for _, spec := range orderSpecs {
+		args = append(args, operandArgs(d, spec.Field)...)
+	}
+	return args
+}
+
+// valueArgs returns the args of a value written by buffer.Value.
+func valueArgs(d Dialect, v any) []any {
+	switch x := v.(type) {
+	case *Field:
+		return nil
+	case expression:
+		return x.args(d)
+	}
+	return []any{v}
+}
+
+// valuesArgs returns the args of the values written by buffer.Value.
+func valuesArgs(d Dialect, values []any) []any {
+	plain := true
+	for _, v := range values {
+		switch v.(type) {
+		case *Field, expression:
+			plain = false
+		}
+	}
+	if plain {
+		return values
+	}
+	args := make([]any, 0, len(values))
+	for _, v := range values {
+		args = append(args, valueArgs(d, v)...)
+	}
+	return args
+}
+
+// withOperand prepends the args of the field of a condition to args.
+func withOperand(d Dialect, field any, args []any) []any {
+	e, ok := field.(expression)
+	if !ok {
+		return args
+	}
+	return append(append([]any(nil), e.args(d)...), args...)
+}
+
+// Excluded refers to a column of the row proposed for insertion, to be used as a
+// value in OnDuplicate, e.g. Set("total", Excluded("total")). It is rendered as
+// `VALUES(col)` on MySQL, as `alias.col` when the row is named with RowAlias and
+// as `EXCLUDED.col` on PostgreSQL and SQLite.
+func Excluded(column string) *ExcludedColumn {
+	return &ExcludedColumn{Column: column}
+}
+
+type ExcludedColumn struct {
+	_expression
+	Column string
+}
+
+func (e *ExcludedColumn) write(buf *buffer) {
+	buf.Inserted(e.Column)
+}
+
+func (e *ExcludedColumn) args(d Dialect) []any {
+	return nil
+}
+
+// Raw is an SQL expression with its args, e.g. Raw("IF(score > ?, 1, 0)", 60). It is
+// accepted wherever a field is, and used as a value, e.g. in Values, it is written
+// as is instead of a placeholder.
+func Raw(expr string, args ...any) *Expr {
+	return &Expr{Expr: expr, Args: args}
+}
+
+// As names the expression when it is selected.
+func (e *Expr) As(alias string) *Expr {
+	e.Alias = alias
+	return e
+}
+
+func (e *Expr) write(buf *buffer) {
+	buf.WriteString(e.Expr)
+}
+
+func (e *Expr) args(d Dialect) []any {
+	return e.Args
+}
+
+// Fn is a call of the SQL function name. An operand is a *Field or an expression,
+// written as is, or an arg bound to a placeholder, e.g. Fn("COALESCE", F("a"), 0)
+// is rendered as `COALESCE(a,?)`.
+func Fn(name string, operands ...any) *FnExpr {
+	return &FnExpr{Name: name, Operands: operands}
+}
+
+type FnExpr struct {
+	_expression
+	Name     string
+	Operands []any
+	Alias    string
+}
+
+// As names the call when it is selected.
+func (f *FnExpr) As(alias string) *FnExpr {
+	f.Alias = alias
+	return f
+}
+
+func (f *FnExpr) write(buf *buffer) {
+	buf.WriteString(f.Name)
+	buf.OpenParen()
+	for i, op := range f.Operands {
+		if i > 0 {
+			buf.Comma()
+		}
+		buf.Value(op)
+	}
+	buf.CloseParen()
+}
+
+func (f *FnExpr) args(d Dialect) []any {
+	var args []any
+	for _, op := range f.Operands {
+		args = append(args, valueArgs(d, op)...)
+	}
+	return args
+}
+
+// Default is the default value of a column, to be used in Values. SQLite does not support it.
+var Default = &DefaultValue{}
+
+type DefaultValue struct {
+	_expression
+}
+
+func (v *DefaultValue) write(buf *buffer) {
+	if buf.dialect == SQLite {
+		buf.setErr(unsupported(buf.dialect, "DEFAULT value"))
+	}
+	buf.WriteString("DEFAULT")
+}
+
+func (v *DefaultValue) args(d Dialect) []any {
+	return nil
+}
@@ -1,5 +1,10 @@
 package sqlbuilder
 
+import (
+	"fmt"
+	"reflect"
+)
+
 type insertBuilder SqlBuilder
 
 type insertBuilderFields SqlBuilder
@@ -12,6 +17,14 @@ type insertBuilderSelect SqlBuilder
 
 type insertBuilderDup SqlBuilder
 
+type insertBuilderConflict SqlBuilder
+
+type insertBuilderConflictUpdate SqlBuilder
+
+type insertBuilderConflictDone SqlBuilder
+
+type insertBuilderReturning SqlBuilder
+
 func (b *insertBuilder) init(kws []Keyword) *insertBuilder {
 	b.buf.WriteString("INSERT")
 	for _, kw := range kws {
@@ -22,14 +35,16 @@ func (b *insertBuilder) init(kws []Keyword) *insertBuilder {
 }
 
 func (b *insertBuilder) Into(table string) *insertBuilderTable {
+	b.target = T(table)
 	b.buf.Space()
 	b.buf.WriteString("INTO")
 	b.buf.Space()
-	b.buf.BackQuoteString(table)
+	b.buf.Quote(table)
 	return (*insertBuilderTable)(b)
 }
 
 func (b *insertBuilder) IntoT(table *Table) *insertBuilderTable {
+	b.target = table
 	b.buf.Space()
 	b.buf.WriteString("INTO")
 	b.buf.Space()
@@ -38,84 +53,396 @@ func (b *insertBuilder) IntoT(table *Table) *insertBuilderTable {
 }
 
 func (b *insertBuilderTable) Fields(fields ...string) *insertBuilderFields {
+	b.columns = fields
 	b.buf.Space()
 	b.buf.OpenParen()
-	b.buf.BackQuoteStrings(fields)
+	b.buf.Quotes(fields)
 	b.buf.CloseParen()
 	return (*insertBuilderFields)(b)
 }
 
-func (b *insertBuilderTable) Select(subquery string, args ...any) *insertBuilderSelect {
+// Structs inserts rows read from the fields tagged with `db:"column[,omitempty][,auto]"`,
+// the columns follow the declaration order of the fields. rows is a struct, a pointer
+// to struct or a slice of them. auto fields, e.g. an auto-increment id, are skipped,
+// omitempty fields are skipped when they are zero in every row.
+func (b *insertBuilderTable) Structs(rows any) *insertBuilderValues {
+	n, columns, argf, err := structRows(rows)
+	if err != nil {
+		b.buf.setErr(err)
+		return (*insertBuilderValues)(b)
+	}
+	return b.Fields(columns...).Bulk(n, argf)
+}
+
+// Pairs inserts a row given as column/value pairs, e.g.
+//
+//	Pairs(Set("name", "alice"), Set("age", 20))
+//
+// is rendered as `(name,age) VALUES (?,?)`.
+func (b *insertBuilderTable) Pairs(vps ...*SetValuer) *insertBuilderValues {
+	b.columns = make([]string, len(vps))
+	for i, vp := range vps {
+		b.columns[i] = b.pairColumn(vp)
+	}
+	b.buf.Space()
+	b.buf.OpenParen()
+	b.buf.Quotes(b.columns)
+	b.buf.CloseParen()
+	(*SqlBuilder)(b).markOutput()
+	b.buf.Space()
+	b.buf.WriteString("VALUES")
+	b.buf.Space()
+	b.buf.OpenParen()
+	for i, vp := range vps {
+		if i > 0 {
+			b.buf.Comma()
+		}
+		vp.writeValue(b.buf)
+		b.args = append(b.args, vp.args(b.dialect)...)
+	}
+	b.buf.CloseParen()
+	return (*insertBuilderValues)(b)
+}
+
+// pairColumn returns the column inserted by vp, which must be named and given a single value.
+func (b *insertBuilderTable) pairColumn(vp *SetValuer) string {
+	column := columnName(vp.Field)
+	if column == "" {
+		b.buf.setErr(fmt.Errorf("%w: cannot insert into %T", ErrInvalidColumn, vp.Field))
+	}
+	if len(vp.Args) != 1 {
+		b.buf.setErr(fmt.Errorf("%w: %d values are given for column %q", ErrColumnCount, len(vp.Args), column))
+	}
+	return column
+}
+
+// PairMap inserts a row given as a map from column to value, in ascending column order.
+func (b *insertBuilderTable) PairMap(m map[string]any) *insertBuilderValues {
+	return b.Pairs(mapPairs(m)...)
+}
+
+// Set inserts a row with the MySQL `INSERT ... SET` syntax. Other dialects render
+// it as Pairs, which requires every assignment to be a Set.
+func (b *insertBuilderTable) Set(vps ...valueUpdater) *insertBuilderValues {
+	if !b.dialect.isMySQL() {
+		pairs := make([]*SetValuer, 0, len(vps))
+		for _, vp := range vps {
+			p, ok := vp.(*SetValuer)
+			if !ok {
+				b.buf.setErr(unsupported(b.dialect, "INSERT ... SET"))
+				return (*insertBuilderValues)(b)
+			}
+			pairs = append(pairs, p)
+		}
+		return b.Pairs(pairs...)
+	}
+	b.columns = make([]string, 0, len(vps))
+	for _, vp := range vps {
+		if p, ok := vp.(*SetValuer); ok {
+			b.columns = append(b.columns, b.pairColumn(p))
+		}
+	}
+	b.buf.Space()
+	b.buf.WriteString("SET")
+	b.buf.Space()
+	b.buf.ValueUpdater(vps)
+	for i := range vps {
+		b.args = append(b.args, vps[i].args(b.dialect)...)
+	}
+	return (*insertBuilderValues)(b)
+}
+
+// SetMap is Set with a map from column to value, in ascending column order.
+func (b *insertBuilderTable) SetMap(m map[string]any) *insertBuilderValues {
+	pairs := mapPairs(m)
+	vps := make([]valueUpdater, len(pairs))
+	for i := range pairs {
+		vps[i] = pairs[i]
+	}
+	return b.Set(vps...)
+}
+
+// DefaultValues inserts a row of default values, rendered as `() VALUES ()` on
+// MySQL and as `DEFAULT VALUES` elsewhere. Oracle does not support it.
+func (b *insertBuilderTable) DefaultValues() *insertBuilderValues {
+	if b.dialect.isMySQL() {
+		b.buf.WriteString(" ()")
+		(*SqlBuilder)(b).markOutput()
+		b.buf.WriteString(" VALUES ()")
+		return (*insertBuilderValues)(b)
+	}
+	if b.dialect == Oracle {
+		b.buf.setErr(unsupported(b.dialect, "DEFAULT VALUES"))
+	}
+	(*SqlBuilder)(b).markOutput()
+	b.buf.WriteString(" DEFAULT VALUES")
+	return (*insertBuilderValues)(b)
+}
+
+// Select inserts the rows of subquery, which is raw SQL of any string type followed
+// by its args, or a select chain carrying its own args without any other arg, e.g.
+// Select(sb.New().Select().Field("id", "name").From("user")).
+func (b *insertBuilderTable) Select(subquery any, args ...any) *insertBuilderSelect {
 	return (*insertBuilderSelect)(b).selectSub(subquery, args)
 }
 
+// Values inserts a row. A value is either an arg, written as a placeholder, or an
+// expression written as is, e.g. Values(sb.Default, sb.Raw("NOW()"), sb.Raw("UUID_TO_BIN(?)", u)).
 func (b *insertBuilderFields) Values(args ...any) *insertBuilderValues {
+	(*SqlBuilder)(b).markOutput()
 	b.buf.Space()
 	b.buf.WriteString("VALUES")
 	b.buf.Space()
 
-	b.buf.WriteString(QuestionMarks(len(args)))
-	b.args = append(b.args, args...)
+	b.args = append(b.args, b.buf.Row(args)...)
 
 	return (*insertBuilderValues)(b)
 }
 
+// Bulk inserts n rows, argf returns the values of the index-th row. A value is
+// either an arg or an expression such as Default or Raw, see Values.
 func (b *insertBuilderFields) Bulk(n int, argf func(index int) []any) *insertBuilderValues {
+	(*SqlBuilder)(b).markOutput()
 	b.buf.Space()
 	b.buf.WriteString("VALUES")
 	b.buf.Space()
 
-	numsOfArgs := -1
-	for i := 0; i < n; i++ {
-		args := argf(i)
-		if numsOfArgs == -1 {
-			numsOfArgs = len(args)
-			b.args = make([]any, 0, n*numsOfArgs)
-		}
-		b.args = append(b.args, args...)
-	}
-
-	qs := QuestionMarks(numsOfArgs)
 	for i := 0; i < n; i++ {
 		if i > 0 {
 			b.buf.WriteByte(',')
 		}
-		b.buf.WriteString(qs)
+		args := b.buf.Row(argf(i))
+		if i == 0 {
+			b.args = append(make([]any, 0, len(b.args)+n*len(args)), b.args...)
+		}
+		b.args = append(b.args, args...)
 	}
 
 	return (*insertBuilderValues)(b)
 }
 
-func (b *insertBuilderFields) Select(subquery string, args ...any) *insertBuilderSelect {
+// Select inserts the rows of subquery, see insertBuilderTable.Select. A select
+// chain with an explicit field list must select as many fields as are inserted.
+func (b *insertBuilderFields) Select(subquery any, args ...any) *insertBuilderSelect {
 	return (*insertBuilderSelect)(b).selectSub(subquery, args)
 }
 
+// RowAlias names the inserted row, so that Excluded and UpdateFromInsert refer to
+// it as `alias.col` instead of `VALUES(col)`, which is deprecated since MySQL 8.0.20.
+// It requires MySQL 8.0.19 or later.
+func (b *insertBuilderValues) RowAlias(alias string) *insertBuilderValues {
+	if b.dialect != MySQL {
+		b.buf.setErr(unsupported(b.dialect, "INSERT row alias"))
+	}
+	b.buf.Space()
+	b.buf.WriteString("AS")
+	b.buf.Space()
+	b.buf.Quote(alias)
+	b.buf.rowAlias = alias
+	return b
+}
+
+// OnDuplicateUpdateAll updates every inserted column except the keys from the
+// row proposed for insertion.
+func (b *insertBuilderValues) OnDuplicateUpdateAll(keys ...string) *insertBuilderDup {
+	skip := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		skip[k] = true
+	}
+	columns := make([]string, 0, len(b.columns))
+	for _, c := range b.columns {
+		if !skip[c] {
+			columns = append(columns, c)
+		}
+	}
+	if len(columns) == 0 {
+		b.buf.setErr(ErrNoColumnToUpdate)
+	}
+	return b.OnDuplicate(UpdateFromInsert(columns...))
+}
+
 func (b *insertBuilderValues) OnDuplicate(vps ...valueUpdater) *insertBuilderDup {
+	if !b.dialect.isMySQL() {
+		b.buf.setErr(unsupported(b.dialect, "ON DUPLICATE KEY UPDATE"))
+	}
 	b.buf.Space()
 	b.buf.WriteString("ON DUPLICATE KEY UPDATE")
 	b.buf.Space()
 	b.buf.ValueUpdater(vps)
 	for i := range vps {
-		b.args = append(b.args, vps[i].args()...)
+		b.args = append(b.args, vps[i].args(b.dialect)...)
 	}
 	return (*insertBuilderDup)(b)
 }
 
+// OnConflict starts the PostgreSQL and SQLite `ON CONFLICT (columns)` clause,
+// the conflict target may be omitted before DoNothing.
+func (b *insertBuilderValues) OnConflict(columns ...string) *insertBuilderConflict {
+	return (*insertBuilderConflict)(b).onConflict(columns)
+}
+
+// OnConflictConstraint starts the PostgreSQL `ON CONFLICT ON CONSTRAINT name` clause.
+func (b *insertBuilderValues) OnConflictConstraint(name string) *insertBuilderConflict {
+	return (*insertBuilderConflict)(b).onConstraint(name)
+}
+
+// Returning returns the given fields of the inserted rows, or all columns when
+// no field is given. It is rendered as `OUTPUT INSERTED.field` on SQL Server and
+// is not supported by MySQL and Oracle.
+func (b *insertBuilderValues) Returning(fields ...any) *insertBuilderReturning {
+	return (*insertBuilderReturning)(b).returning(fields)
+}
+
 func (b *insertBuilderValues) Build() (string, []any) {
 	return (*sqlBuilderBuild)(b).Build()
 }
 
-func (b *insertBuilderSelect) selectSub(subquery string, args []any) *insertBuilderSelect {
+func (b *insertBuilderSelect) selectSub(subquery any, args []any) *insertBuilderSelect {
+	(*SqlBuilder)(b).markOutput()
 	b.buf.Space()
-	b.buf.WriteString(subquery)
-	b.args = append(b.args, args...)
+	if v := reflect.ValueOf(subquery); v.Kind() == reflect.String {
+		b.buf.WriteString(v.String())
+		b.args = append(b.args, args...)
+		return b
+	}
+	q, ok := selectQuery(subquery)
+	if !ok {
+		b.buf.setErr(fmt.Errorf("%w: got %T", ErrSubquery, subquery))
+		return b
+	}
+	if len(args) > 0 {
+		b.buf.setErr(ErrSubqueryArgs)
+	}
+	if q.dialect != b.dialect {
+		b.buf.setErr(fmt.Errorf("%w: select is built for %s, not %s", ErrDialectMismatch, q.dialect, b.dialect))
+	}
+	if q.selected > 0 && len(b.columns) > 0 && q.selected != len(b.columns) {
+		b.buf.setErr(fmt.Errorf("%w: %d columns are inserted from %d selected fields", ErrColumnCount, len(b.columns), q.selected))
+	}
+	sql, qargs := (*sqlBuilderBuild)(q).Build()
+	if q.err != nil {
+		b.buf.setErr(q.err)
+	}
+	b.buf.WriteString(sql)
+	b.args = append(b.args, qargs...)
 	return b
 }
 
+func (b *insertBuilderSelect) OnConflict(columns ...string) *insertBuilderConflict {
+	return (*insertBuilderConflict)(b).onConflict(columns)
+}
+
+func (b *insertBuilderSelect) OnConflictConstraint(name string) *insertBuilderConflict {
+	return (*insertBuilderConflict)(b).onConstraint(name)
+}
+
+func (b *insertBuilderSelect) Returning(fields ...any) *insertBuilderReturning {
+	return (*insertBuilderReturning)(b).returning(fields)
+}
+
 func (b *insertBuilderSelect) Build() (string, []any) {
 	return (*sqlBuilderBuild)(b).Build()
 }
 
+func (b *insertBuilderDup) Returning(fields ...any) *insertBuilderReturning {
+	return (*insertBuilderReturning)(b).returning(fields)
+}
+
 func (b *insertBuilderDup) Build() (string, []any) {
 	return (*sqlBuilderBuild)(b).Build()
 }
+
+func (b *insertBuilderConflict) onConflict(columns []string) *insertBuilderConflict {
+	if b.dialect != PostgreSQL && b.dialect != SQLite {
+		b.buf.setErr(unsupported(b.dialect, "ON CONFLICT"))
+	}
+	b.buf.Space()
+	b.buf.WriteString("ON CONFLICT")
+	if len(columns) > 0 {
+		b.hasConflictTarget = true
+		b.buf.Space()
+		b.buf.OpenParen()
+		b.buf.Quotes(columns)
+		b.buf.CloseParen()
+	}
+	return b
+}
+
+func (b *insertBuilderConflict) onConstraint(name string) *insertBuilderConflict {
+	if b.dialect != PostgreSQL {
+		b.buf.setErr(unsupported(b.dialect, "ON CONFLICT ON CONSTRAINT"))
+	}
+	b.buf.Space()
+	b.buf.WriteString("ON CONFLICT ON CONSTRAINT")
+	b.buf.Space()
+	b.buf.Quote(name)
+	b.hasConflictTarget = true
+	return b
+}
+
+// Where specifies the predicate of a partial unique index used as the conflict target.
+func (b *insertBuilderConflict) Where(conditions ...whereCondition) *insertBuilderConflict {
+	(*SqlBuilder)(b).whereClause(conditions)
+	return b
+}
+
+func (b *insertBuilderConflict) DoNothing() *insertBuilderConflictDone {
+	b.buf.Space()
+	b.buf.WriteString("DO NOTHING")
+	return (*insertBuilderConflictDone)(b)
+}
+
+// DoUpdate updates the conflicting row, use Excluded to refer to the row proposed for insertion.
+// It requires a conflict target. The columns read by Incr, Decr and SetColumn are
+// qualified with the table on PostgreSQL, e.g. "n"="t"."n"+?.
+func (b *insertBuilderConflict) DoUpdate(vps ...valueUpdater) *insertBuilderConflictUpdate {
+	if !b.hasConflictTarget {
+		b.buf.setErr(fmt.Errorf("%w: DO UPDATE requires a conflict target", ErrNoConflictKey))
+	}
+	if len(vps) == 0 {
+		b.buf.setErr(ErrNoColumnToUpdate)
+	}
+	b.buf.Space()
+	b.buf.WriteString("DO UPDATE SET")
+	b.buf.Space()
+	if b.dialect == PostgreSQL {
+		b.buf.conflictTable = b.target
+	}
+	b.buf.ValueUpdater(vps)
+	b.buf.conflictTable = nil
+	for i := range vps {
+		b.args = append(b.args, vps[i].args(b.dialect)...)
+	}
+	return (*insertBuilderConflictUpdate)(b)
+}
+
+// Where limits the rows updated by DoUpdate.
+func (b *insertBuilderConflictUpdate) Where(conditions ...whereCondition) *insertBuilderConflictDone {
+	(*SqlBuilder)(b).whereClause(conditions)
+	return (*insertBuilderConflictDone)(b)
+}
+
+func (b *insertBuilderConflictUpdate) Returning(fields ...any) *insertBuilderReturning {
+	return (*insertBuilderReturning)(b).returning(fields)
+}
+
+func (b *insertBuilderConflictUpdate) Build() (string, []any) {
+	return (*sqlBuilderBuild)(b).Build()
+}
+
+func (b *insertBuilderConflictDone) Returning(fields ...any) *insertBuilderReturning {
+	return (*insertBuilderReturning)(b).returning(fields)
+}
+
+func (b *insertBuilderConflictDone) Build() (string, []any) {
+	return (*sqlBuilderBuild)(b).Build()
+}
+
+func (b *insertBuilderReturning) returning(fields []any) *insertBuilderReturning {
+	(*SqlBuilder)(b).returning("INSERT", fields)
+	return b
+}
+
+func (b *insertBuilderReturning) Build() (string, []any) {
+	return (*sqlBuilderBuild)(b).Build()
+}
@@ -0,0 +1,162 @@
+package sqlbuilder
+
+// The join helpers are shared by the builders which support a join stage.
+
+// fromList tracks the joins of a multi-table UPDATE or DELETE which the dialect
+// renders as a FROM/USING list, e.g. PostgreSQL `UPDATE ... SET ... FROM ...`.
+// The first joined table heads the list and its join condition is moved into WHERE.
+type fromList struct {
+	mark    int
+	argMark int
+	head    *Table
+	first   bool
+	on      string
+	onArgs  []any
+}
+
+func (b *SqlBuilder) join(joinType Keyword, table *Table) {
+	if !b.dialect.supportsJoin(joinType) {
+		b.buf.setErr(unsupported(b.dialect, string(joinType)))
+	}
+	b.buf.Space()
+	b.buf.WriteString(string(joinType))
+	b.buf.Space()
+	b.buf.Table(table)
+}
+
+func (b *SqlBuilder) joinLateral(joinType Keyword, sub *Subquery) {
+	b.buf.Space()
+	if b.dialect == SQLServer && joinType == crossJoin {
+		b.buf.WriteString("CROSS APPLY")
+	} else {
+		if !b.dialect.supportsLateral() {
+			b.buf.setErr(unsupported(b.dialect, string(lateral)))
+		}
+		b.buf.WriteString(string(joinType))
+		b.buf.Space()
+		b.buf.WriteString(string(lateral))
+	}
+	b.buf.Space()
+	b.buf.Subquery(sub)
+	b.args = append(b.args, sub.Args...)
+}
+
+// joinFrom writes a join of a multi-table UPDATE or DELETE. MySQL joins the tables
+// in place, PostgreSQL and SQLite start a FROM/USING list with the first table,
+// keyword is written before it unless the builder writes the list elsewhere.
+func (b *SqlBuilder) joinFrom(stmt string, joinType Keyword, table *Table, keyword string) {
+	switch {
+	case !b.dialect.supportsJoinFrom(stmt):
+		b.buf.setErr(unsupported(b.dialect, "multi-table "+stmt))
+		b.join(joinType, table)
+		return
+	case b.dialect.isMySQL() || b.from != nil:
+		b.join(joinType, table)
+		return
+	case joinType != innerJoin && joinType != crossJoin:
+		b.buf.setErr(unsupported(b.dialect, string(joinType)+" as the first join of multi-table "+stmt))
+	}
+	b.from = &fromList{
+		mark:    b.buf.Len(),
+		argMark: len(b.args),
+		head:    table,
+		first:   joinType != crossJoin,
+	}
+	b.buf.Space()
+	if keyword != "" {
+		b.buf.WriteString(keyword)
+		b.buf.Space()
+	}
+	b.buf.Table(table)
+}
+
+// headCondition moves the join condition written by fn into the WHERE clause if
+// it belongs to the head of a from list.
+func (b *SqlBuilder) headCondition(fn func()) bool {
+	if b.from == nil || !b.from.first {
+		return false
+	}
+	b.from.first = false
+	mark, argMark := b.buf.Len(), len(b.args)
+	fn()
+	b.from.on, b.from.onArgs = b.cut(mark, argMark)
+	return true
+}
+
+// cut removes what has been written since the marks and returns it.
+func (b *SqlBuilder) cut(mark, argMark int) (string, []any) {
+	sql := string(b.buf.Bytes()[mark:])
+	b.buf.Truncate(mark)
+	args := append([]any(nil), b.args[argMark:]...)
+	b.args = b.args[:argMark]
+	return sql, args
+}
+
+// whereFrom opens the WHERE clause with the join condition of the from list head.
+func (b *SqlBuilder) whereFrom() {
+	if b.from == nil || b.from.on == "" {
+		return
+	}
+	b.buf.Space()
+	b.buf.WriteString("WHERE")
+	b.buf.Space()
+	b.buf.WriteString(b.from.on)
+	b.args = append(b.args, b.from.onArgs...)
+	b.from.on, b.from.onArgs = "", nil
+	b.hasWhere = true
+}
+
+func (b *SqlBuilder) on(lhs, rhs *Field) {
+	if b.headCondition(func() {
+		b.buf.FieldName(lhs)
+		b.buf.Equal()
+		b.buf.FieldName(rhs)
+	}) {
+		return
+	}
+	b.buf.Space()
+	b.buf.WriteString("ON")
+	b.buf.Space()
+	b.buf.Field(lhs)
+	b.buf.Equal()
+	b.buf.Field(rhs)
+}
+
+func (b *SqlBuilder) onConditions(conditions []whereCondition) {
+	if b.headCondition(func() {
+		b.conditions(conditions)
+	}) {
+		return
+	}
+	b.buf.Space()
+	b.buf.WriteString("ON")
+	b.buf.Space()
+	b.conditions(conditions)
+}
+
+func (b *SqlBuilder) using(fields []string) {
+	if b.headCondition(func() {
+		for i, f := range fields {
+			if i > 0 {
+				b.buf.Space()
+				b.buf.WriteString(string(AndOperator))
+				b.buf.Space()
+			}
+			b.buf.TableRef(b.target)
+			b.buf.Dot()
+			b.buf.Quote(f)
+			b.buf.Equal()
+			b.buf.TableRef(b.from.head)
+			b.buf.Dot()
+			b.buf.Quote(f)
+		}
+	}) {
+		return
+	}
+	b.buf.Space()
+	b.buf.WriteString("USING")
+	b.buf.Space()
+	b.buf.OpenParen()
+	b.buf.Quotes(fields)
+	b.buf.CloseParen()
+}
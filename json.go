@@ -0,0 +1,475 @@
+package sqlbuilder
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSON refers to a JSON column, or to a value inside it with Path, e.g.
+//
+//	Eq(JSON(F("attrs")).Path("$.color").Text(), "red")
+//
+// is rendered as `attrs`->>'$.color' = ? on MySQL and as "attrs"->>'color' = ?
+// on PostgreSQL. As the target of Set it replaces the value at the path, with
+// JSON_SET on MySQL and jsonb_set on PostgreSQL. A map, a slice or a struct set
+// at the path is bound as JSON.
+func JSON(field any) *JSONExpr {
+	return &JSONExpr{Field: field}
+}
+
+type JSONExpr struct {
+	_expression
+	Field any
+	// JSONPath is a MySQL path made of .key, ."quoted key" and [index] steps,
+	// it is written as a literal.
+	JSONPath string
+	// Unquoted extracts the value as text rather than as JSON.
+	Unquoted bool
+	Alias    string
+}
+
+// Path refers to the value at path, e.g. "$.sizes[0]".
+func (j *JSONExpr) Path(path string) *JSONExpr {
+	j.JSONPath = path
+	return j
+}
+
+// Text extracts the value as text, `->>` rather than `->`.
+func (j *JSONExpr) Text() *JSONExpr {
+	j.Unquoted = true
+	return j
+}
+
+// As names the value when it is selected.
+func (j *JSONExpr) As(alias string) *JSONExpr {
+	j.Alias = alias
+	return j
+}
+
+func (j *JSONExpr) write(buf *buffer) {
+	if j.JSONPath == "" {
+		buf.Operand(j.Field)
+		return
+	}
+	switch d := buf.dialect; d {
+	case MySQL, SQLite:
+		buf.Operand(j.Field)
+		if j.Unquoted {
+			buf.WriteString("->>")
+		} else {
+			buf.WriteString("->")
+		}
+		buf.StringLiteral(j.JSONPath)
+	case MariaDB:
+		// MariaDB has no -> operator.
+		if j.Unquoted {
+			buf.WriteString("JSON_UNQUOTE(")
+		}
+		buf.WriteString("JSON_EXTRACT(")
+		buf.Operand(j.Field)
+		buf.Comma()
+		buf.StringLiteral(j.JSONPath)
+		buf.CloseParen()
+		if j.Unquoted {
+			buf.CloseParen()
+		}
+	case PostgreSQL:
+		steps, err := parseJSONPath(j.JSONPath)
+		if err != nil {
+			buf.setErr(err)
+		}
+		buf.Operand(j.Field)
+		for i, s := range steps {
+			if j.Unquoted && i == len(steps)-1 {
+				buf.WriteString("->>")
+			} else {
+				buf.WriteString("->")
+			}
+			if s.isIndex {
+				buf.WriteString(strconv.Itoa(s.index))
+			} else {
+				buf.StringLiteral(s.key)
+			}
+		}
+		if j.Unquoted && len(steps) == 0 {
+			buf.WriteString("#>>'{}'")
+		}
+	default:
+		if j.Unquoted {
+			buf.WriteString("JSON_VALUE(")
+		} else {
+			buf.WriteString("JSON_QUERY(")
+		}
+		buf.Operand(j.Field)
+		buf.Comma()
+		buf.StringLiteral(j.JSONPath)
+		buf.CloseParen()
+	}
+}
+
+func (j *JSONExpr) args(d Dialect) []any {
+	return operandArgs(d, j.Field)
+}
+
+// writeSet writes the assignment of the value at the path, value writes the new value.
+func (j *JSONExpr) writeSet(buf *buffer, value func()) {
+	buf.SetTarget(j.Field)
+	buf.Equal()
+	if j.JSONPath == "" {
+		value()
+		return
+	}
+	path := j.JSONPath
+	switch d := buf.dialect; {
+	case d.isMySQL():
+		buf.WriteString("JSON_SET(")
+	case d == SQLite:
+		buf.WriteString("json_set(")
+	case d == SQLServer:
+		buf.WriteString("JSON_MODIFY(")
+	case d == PostgreSQL:
+		buf.WriteString("jsonb_set(")
+		steps, err := parseJSONPath(path)
+		if err != nil {
+			buf.setErr(err)
+		}
+		path = pgPathArray(steps)
+	default:
+		buf.setErr(unsupported(d, "JSON_SET"))
+		buf.WriteString("JSON_SET(")
+	}
+	buf.Operand(j.Field)
+	buf.Comma()
+	buf.StringLiteral(path)
+	buf.Comma()
+	value()
+	buf.CloseParen()
+}
+
+// writeSetArg writes the placeholder of the value assigned at the path. A JSON
+// document is bound encoded and converted back to JSON, so that it is not set
+// as a string.
+func (j *JSONExpr) writeSetArg(buf *buffer, v any) {
+	d := buf.dialect
+	if !j.encoded(d, v) {
+		buf.Question()
+		return
+	}
+	if _, err := jsonArg(v); err != nil {
+		buf.setErr(err)
+	}
+	switch d {
+	case MySQL:
+		buf.WriteString("CAST(? AS JSON)")
+	case MariaDB:
+		// MariaDB has no JSON type to cast to.
+		buf.WriteString("JSON_EXTRACT(?,'$')")
+	case SQLite:
+		buf.WriteString("json(?)")
+	case SQLServer:
+		buf.WriteString("JSON_QUERY(?)")
+	default:
+		buf.Question()
+	}
+}
+
+// setArg returns the arg of the value assigned at the path, see writeSetArg.
+func (j *JSONExpr) setArg(d Dialect, v any) any {
+	if !j.encoded(d, v) {
+		return v
+	}
+	s, _ := jsonArg(v)
+	return s
+}
+
+// encoded reports whether the value assigned at the path is bound as JSON, which
+// is always the case on PostgreSQL whose jsonb_set expects a jsonb value.
+func (j *JSONExpr) encoded(d Dialect, v any) bool {
+	return j.JSONPath != "" && (d == PostgreSQL || jsonDocument(v))
+}
+
+// jsonDocument reports whether v is encoded as a JSON object or array rather
+// than bound as a scalar: a json.RawMessage, a map, a slice or an array other
+// than bytes and a struct other than time.Time.
+func jsonDocument(v any) bool {
+	switch v.(type) {
+	case json.RawMessage:
+		return true
+	case driver.Valuer, time.Time:
+		return false
+	}
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	switch rv.Kind() {
+	case reflect.Map:
+		return true
+	case reflect.Slice, reflect.Array:
+		return rv.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Struct:
+		return rv.Type() != reflect.TypeOf(time.Time{})
+	}
+	return false
+}
+
+// checkJSONSets records the JSON columns of which several paths are set. Only
+// MySQL applies the assignments of a column one after the other, PostgreSQL and
+// SQL Server reject them and SQLite keeps the last one.
+func checkJSONSets(buf *buffer, vps []valueUpdater) {
+	if buf.dialect.isMySQL() {
+		return
+	}
+	seen := make(map[string]bool)
+	for _, vp := range vps {
+		v, ok := vp.(*SetValuer)
+		if !ok {
+			continue
+		}
+		j, ok := v.Field.(*JSONExpr)
+		if !ok || j.JSONPath == "" {
+			continue
+		}
+		name := columnName(j.Field)
+		if name == "" {
+			continue
+		}
+		if seen[name] {
+			buf.setErr(fmt.Errorf("%w: several paths of %s are set", ErrDuplicateColumn, name))
+			return
+		}
+		seen[name] = true
+	}
+}
+
+type jsonStep struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parseJSONPath splits a MySQL path into its steps, wildcards and ranges are not supported.
+func parseJSONPath(path string) ([]jsonStep, error) {
+	invalid := fmt.Errorf("%w: %q", ErrJSONPath, path)
+	if !strings.HasPrefix(path, "$") {
+		return nil, invalid
+	}
+	var steps []jsonStep
+	for p := path[1:]; p != ""; {
+		switch p[0] {
+		case '.':
+			p = p[1:]
+			if strings.HasPrefix(p, `"`) {
+				end := strings.IndexByte(p[1:], '"')
+				if end < 0 {
+					return nil, invalid
+				}
+				steps = append(steps, jsonStep{key: p[1 : end+1]})
+				p = p[end+2:]
+				continue
+			}
+			end := strings.IndexAny(p, ".[")
+			if end < 0 {
+				end = len(p)
+			}
+			key := p[:end]
+			if key == "" || strings.Contains(key, "*") {
+				return nil, invalid
+			}
+			steps = append(steps, jsonStep{key: key})
+			p = p[end:]
+		case '[':
+			end := strings.IndexByte(p, ']')
+			if end < 0 {
+				return nil, invalid
+			}
+			index, err := strconv.Atoi(p[1:end])
+			if err != nil || index < 0 {
+				return nil, invalid
+			}
+			steps = append(steps, jsonStep{index: index, isIndex: true})
+			p = p[end+1:]
+		default:
+			return nil, invalid
+		}
+	}
+	return steps, nil
+}
+
+// pgPathArray returns the text[] path of jsonb_set, e.g. '{sizes,0}'.
+func pgPathArray(steps []jsonStep) string {
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, s := range steps {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		if s.isIndex {
+			sb.WriteString(strconv.Itoa(s.index))
+			continue
+		}
+		sb.WriteByte('"')
+		sb.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s.key))
+		sb.WriteByte('"')
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// jsonArg encodes v as JSON, json.RawMessage and []byte are used as is.
+func jsonArg(v any) (string, error) {
+	switch x := v.(type) {
+	case json.RawMessage:
+		return string(x), nil
+	case []byte:
+		return string(x), nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+type jsonOp int
+
+const (
+	jsonContains jsonOp = iota
+	jsonOverlaps
+	jsonMemberOf
+	jsonHasKey
+)
+
+// JSONContains matches the documents of field containing value, which is
+// encoded as JSON. It is rendered as JSON_CONTAINS on MySQL and as @> on PostgreSQL.
+func JSONContains(field any, value any) *JSONCondition {
+	return &JSONCondition{Field: field, Op: jsonContains, Value: value}
+}
+
+// JSONOverlaps matches the documents of field sharing an element with value,
+// which is encoded as JSON. PostgreSQL, which has no JSON_OVERLAPS, checks
+// whether the document contains any element of the array value.
+func JSONOverlaps(field any, value any) *JSONCondition {
+	return &JSONCondition{Field: field, Op: jsonOverlaps, Value: value}
+}
+
+// MemberOf matches the arrays of field containing value, `? MEMBER OF(field)`.
+func MemberOf(value any, field any) *JSONCondition {
+	return &JSONCondition{Field: field, Op: jsonMemberOf, Value: value}
+}
+
+// JSONHasKey matches the objects of field having the key. It is rendered as
+// jsonb_exists on PostgreSQL, whose ? operator would be taken for a placeholder.
+func JSONHasKey(field any, key string) *JSONCondition {
+	return &JSONCondition{Field: field, Op: jsonHasKey, Value: key}
+}
+
+type JSONCondition struct {
+	_whereCondition
+	Field any
+	Op    jsonOp
+	Value any
+}
+
+func (c *JSONCondition) write(buf *buffer) {
+	d := buf.dialect
+	if !d.isMySQL() && d != PostgreSQL {
+		buf.setErr(unsupported(d, "JSON condition"))
+	}
+	if c.encoded(d) {
+		if _, err := jsonArg(c.Value); err != nil {
+			buf.setErr(err)
+		}
+	}
+	switch c.Op {
+	case jsonContains:
+		if d == PostgreSQL {
+			c.writeContains(buf)
+		} else {
+			c.writeFunc(buf, "JSON_CONTAINS")
+		}
+	case jsonOverlaps:
+		if d == PostgreSQL {
+			buf.WriteString(`EXISTS (SELECT 1 FROM jsonb_array_elements(?) AS "e"("v") WHERE `)
+			buf.Operand(c.Field)
+			buf.WriteString(` @> jsonb_build_array("v"))`)
+		} else {
+			c.writeFunc(buf, "JSON_OVERLAPS")
+		}
+	case jsonMemberOf:
+		switch d {
+		case PostgreSQL:
+			c.writeContains(buf)
+		case MariaDB:
+			// MariaDB has no MEMBER OF.
+			c.writeFunc(buf, "JSON_CONTAINS")
+		default:
+			buf.Value(c.Value)
+			buf.WriteString(" MEMBER OF(")
+			buf.Operand(c.Field)
+			buf.CloseParen()
+		}
+	case jsonHasKey:
+		key, _ := c.Value.(string)
+		if d == PostgreSQL {
+			buf.WriteString("jsonb_exists(")
+			buf.Operand(c.Field)
+			buf.Comma()
+			buf.StringLiteral(key)
+			buf.CloseParen()
+			return
+		}
+		buf.WriteString("JSON_CONTAINS_PATH(")
+		buf.Operand(c.Field)
+		buf.WriteString(",'one',")
+		buf.StringLiteral(`$."` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(key) + `"`)
+		buf.CloseParen()
+	}
+}
+
+// encoded reports whether the value is bound as JSON.
+func (c *JSONCondition) encoded(d Dialect) bool {
+	switch c.Op {
+	case jsonHasKey:
+		return false
+	case jsonMemberOf:
+		return d == PostgreSQL || d == MariaDB
+	}
+	return true
+}
+
+func (c *JSONCondition) writeContains(buf *buffer) {
+	buf.Operand(c.Field)
+	buf.WriteString(" @> ?")
+}
+
+func (c *JSONCondition) writeFunc(buf *buffer, name string) {
+	buf.WriteString(name)
+	buf.OpenParen()
+	buf.Operand(c.Field)
+	buf.WriteString(",?)")
+}
+
+func (c *JSONCondition) args(d Dialect) []any {
+	switch c.Op {
+	case jsonHasKey:
+		return operandArgs(d, c.Field)
+	case jsonMemberOf:
+		if d == PostgreSQL {
+			s, _ := jsonArg([]any{c.Value})
+			return withOperand(d, c.Field, []any{s})
+		}
+		if d != MariaDB {
+			return append(valueArgs(d, c.Value), operandArgs(d, c.Field)...)
+		}
+	case jsonOverlaps:
+		if d == PostgreSQL {
+			s, _ := jsonArg(c.Value)
+			if !strings.HasPrefix(s, "[") {
+				s = "[" + s + "]"
+			}
+			return append([]any{s}, operandArgs(d, c.Field)...)
+		}
+	}
+	s, _ := jsonArg(c.Value)
+	return withOperand(d, c.Field, []any{s})
+}
@@ -0,0 +1,167 @@
+package sqlbuilder
+
+import "fmt"
+
+// Match starts a full-text search of the fields, e.g.
+//
+//	Match("title", "body").Against("+go -java", BooleanMode)
+//
+// It is a condition in Where and the relevance of the row in Field and OrderBy.
+// MySQL renders MATCH (...) AGAINST (...), PostgreSQL searches to_tsvector(...)
+// with @@ and scores it with ts_rank, SQL Server uses CONTAINS or FREETEXT,
+// SQLite and Oracle search a single field with MATCH and CONTAINS.
+func Match(fields ...any) *MatchExpr {
+	return &MatchExpr{Fields: fields}
+}
+
+type MatchExpr struct {
+	_whereCondition
+	_expression
+	Fields []any
+	Query  any
+	Mode   MatchMode
+	Alias  string
+}
+
+// Against sets the searched query, the natural language mode is used if mode is empty.
+func (m *MatchExpr) Against(query any, mode MatchMode) *MatchExpr {
+	m.Query = query
+	m.Mode = mode
+	return m
+}
+
+// As names the relevance when it is selected.
+func (m *MatchExpr) As(alias string) *MatchExpr {
+	m.Alias = alias
+	return m
+}
+
+// write writes the search condition.
+func (m *MatchExpr) write(buf *buffer) {
+	d := buf.dialect
+	m.check(buf)
+	switch {
+	case d.isMySQL():
+		m.writeMySQL(buf)
+	case d == PostgreSQL:
+		m.writeVector(buf)
+		buf.WriteString(" @@ ")
+		m.writeQuery(buf)
+	case d == SQLServer:
+		if m.Mode == BooleanMode {
+			buf.WriteString("CONTAINS(")
+		} else {
+			buf.WriteString("FREETEXT(")
+		}
+		if len(m.Fields) > 1 {
+			buf.OpenParen()
+		}
+		m.writeFields(buf)
+		if len(m.Fields) > 1 {
+			buf.CloseParen()
+		}
+		buf.Comma()
+		buf.Value(m.Query)
+		buf.CloseParen()
+	case d == SQLite:
+		m.writeFields(buf)
+		buf.WriteString(" MATCH ")
+		buf.Value(m.Query)
+	case d == Oracle:
+		buf.WriteString("CONTAINS(")
+		m.writeFields(buf)
+		buf.Comma()
+		buf.Value(m.Query)
+		buf.WriteString(") > 0")
+	}
+}
+
+// writeScore writes the relevance of the row.
+func (m *MatchExpr) writeScore(buf *buffer) {
+	d := buf.dialect
+	m.check(buf)
+	switch {
+	case d.isMySQL():
+		m.writeMySQL(buf)
+	case d == PostgreSQL:
+		buf.WriteString("ts_rank(")
+		m.writeVector(buf)
+		buf.Comma()
+		m.writeQuery(buf)
+		buf.CloseParen()
+	default:
+		buf.setErr(unsupported(d, "full-text search relevance"))
+	}
+}
+
+// check records the invalid searches and the ones the dialect cannot express.
+func (m *MatchExpr) check(buf *buffer) {
+	d := buf.dialect
+	switch {
+	case len(m.Fields) == 0:
+		buf.setErr(fmt.Errorf("%w: full-text search", ErrNoField))
+	case m.Query == nil:
+		buf.setErr(ErrNoQuery)
+	case !m.Mode.valid():
+		buf.setErr(fmt.Errorf("%w: %q", ErrMatchMode, string(m.Mode)))
+	case d.isMySQL():
+	case m.Mode == QueryExpansion:
+		buf.setErr(unsupported(d, "full-text search "+string(QueryExpansion)))
+	case len(m.Fields) > 1 && (d == SQLite || d == Oracle):
+		buf.setErr(unsupported(d, "full-text search of several fields"))
+	}
+}
+
+func (m *MatchExpr) writeMySQL(buf *buffer) {
+	buf.WriteString("MATCH ")
+	buf.OpenParen()
+	m.writeFields(buf)
+	buf.WriteString(") AGAINST ")
+	buf.OpenParen()
+	buf.Value(m.Query)
+	if m.Mode != "" && m.Mode.valid() {
+		buf.Space()
+		buf.WriteString(string(m.Mode))
+	}
+	buf.CloseParen()
+}
+
+// writeVector writes the document searched on PostgreSQL, the fields are
+// concatenated with a space.
+func (m *MatchExpr) writeVector(buf *buffer) {
+	buf.WriteString("to_tsvector(")
+	if len(m.Fields) > 1 {
+		buf.WriteString("concat_ws(' ',")
+		m.writeFields(buf)
+		buf.CloseParen()
+	} else {
+		m.writeFields(buf)
+	}
+	buf.CloseParen()
+}
+
+// writeQuery writes the query on PostgreSQL, websearch_to_tsquery accepts the
+// quotes, OR and - of a boolean search.
+func (m *MatchExpr) writeQuery(buf *buffer) {
+	if m.Mode == BooleanMode {
+		buf.WriteString("websearch_to_tsquery(")
+	} else {
+		buf.WriteString("plainto_tsquery(")
+	}
+	buf.Value(m.Query)
+	buf.CloseParen()
+}
+
+func (m *MatchExpr) writeFields(buf *buffer) {
+	for i, f := range m.Fields {
+		if i > 0 {
+			buf.Comma()
+		}
+		buf.Operand(f)
+	}
+}
+
+func (m *MatchExpr) args(d Dialect) []any {
+	args := fieldsArgs(d, m.Fields)
+	return append(args, valueArgs(d, m.Query)...)
+}
@@ -0,0 +1,72 @@
+package sqlbuilder
+
+type replaceBuilder SqlBuilder
+
+type replaceBuilderTable SqlBuilder
+
+type replaceBuilderFields SqlBuilder
+
+type replaceBuilderValues SqlBuilder
+
+type replaceBuilderSelect SqlBuilder
+
+// init writes `REPLACE`, which is `INSERT OR REPLACE` on SQLite.
+func (b *replaceBuilder) init(kws []Keyword) *replaceBuilder {
+	switch {
+	case b.dialect == SQLite:
+		b.buf.WriteString("INSERT OR REPLACE")
+	case b.dialect.isMySQL():
+		b.buf.WriteString("REPLACE")
+	default:
+		b.buf.setErr(unsupported(b.dialect, "REPLACE"))
+		b.buf.WriteString("REPLACE")
+	}
+	for _, kw := range kws {
+		b.buf.Space()
+		b.buf.WriteString(string(kw))
+	}
+	return b
+}
+
+func (b *replaceBuilder) Into(table string) *replaceBuilderTable {
+	(*insertBuilder)(b).Into(table)
+	return (*replaceBuilderTable)(b)
+}
+
+func (b *replaceBuilder) IntoT(table *Table) *replaceBuilderTable {
+	(*insertBuilder)(b).IntoT(table)
+	return (*replaceBuilderTable)(b)
+}
+
+func (b *replaceBuilderTable) Fields(fields ...string) *replaceBuilderFields {
+	(*insertBuilderTable)(b).Fields(fields...)
+	return (*replaceBuilderFields)(b)
+}
+
+func (b *replaceBuilderTable) Select(subquery any, args ...any) *replaceBuilderSelect {
+	(*insertBuilderTable)(b).Select(subquery, args...)
+	return (*replaceBuilderSelect)(b)
+}
+
+func (b *replaceBuilderFields) Values(args ...any) *replaceBuilderValues {
+	(*insertBuilderFields)(b).Values(args...)
+	return (*replaceBuilderValues)(b)
+}
+
+func (b *replaceBuilderFields) Bulk(n int, argf func(index int) []any) *replaceBuilderValues {
+	(*insertBuilderFields)(b).Bulk(n, argf)
+	return (*replaceBuilderValues)(b)
+}
+
+func (b *replaceBuilderFields) Select(subquery any, args ...any) *replaceBuilderSelect {
+	(*insertBuilderFields)(b).Select(subquery, args...)
+	return (*replaceBuilderSelect)(b)
+}
+
+func (b *replaceBuilderValues) Build() (string, []any) {
+	return (*sqlBuilderBuild)(b).Build()
+}
+
+func (b *replaceBuilderSelect) Build() (string, []any) {
+	return (*sqlBuilderBuild)(b).Build()
+}
@@ -1,5 +1,7 @@
 package sqlbuilder
 
+import "strings"
+
 type selectBuilder SqlBuilder
 
 type selectBuilderExpr SqlBuilder
@@ -14,6 +16,8 @@ type selectBuilderWhere SqlBuilder
 
 type selectBuilderGroup SqlBuilder
 
+type selectBuilderHaving SqlBuilder
+
 type selectBuilderOrder SqlBuilder
 
 type selectBuilderLimit SqlBuilder
@@ -33,15 +37,49 @@ func (b *selectBuilder) Field(fields ...any) *selectBuilderExpr {
 		b.buf.WriteByte('*')
 	} else {
 		b.buf.AnyFields(fields)
+		b.args = append(b.args, fieldsArgs(b.dialect, fields)...)
+		b.selected = countFields(fields)
 	}
 	return (*selectBuilderExpr)(b)
 }
 
+// countFields returns the number of columns selected by fields, 0 if an
+// expression such as `t.*` selects an unknown number of them.
+func countFields(fields []any) int {
+	for _, f := range fields {
+		if e, ok := f.(*Expr); ok && strings.HasSuffix(strings.TrimSpace(e.Expr), "*") {
+			return 0
+		}
+	}
+	return len(fields)
+}
+
+// selectQuery returns the builder of a select chain which can be built.
+func selectQuery(v any) (*SqlBuilder, bool) {
+	switch q := v.(type) {
+	case *selectBuilderTable:
+		return (*SqlBuilder)(q), true
+	case *selectBuilderJoinSpec:
+		return (*SqlBuilder)(q), true
+	case *selectBuilderWhere:
+		return (*SqlBuilder)(q), true
+	case *selectBuilderGroup:
+		return (*SqlBuilder)(q), true
+	case *selectBuilderHaving:
+		return (*SqlBuilder)(q), true
+	case *selectBuilderOrder:
+		return (*SqlBuilder)(q), true
+	case *selectBuilderLimit:
+		return (*SqlBuilder)(q), true
+	}
+	return nil, false
+}
+
 func (b *selectBuilderExpr) From(tables ...string) *selectBuilderTable {
 	b.buf.Space()
 	b.buf.WriteString("FROM")
 	b.buf.Space()
-	b.buf.BackQuoteStrings(tables)
+	b.buf.Quotes(tables)
 	return (*selectBuilderTable)(b)
 }
 
@@ -66,6 +104,37 @@ func (b *selectBuilderTable) InnerJoin(table *Table) *selectBuilderJoin {
 	return (*selectBuilderJoin)(b).join(innerJoin, table)
 }
 
+// FullJoin is not supported by MySQL.
+func (b *selectBuilderTable) FullJoin(table *Table) *selectBuilderJoin {
+	return (*selectBuilderJoin)(b).join(fullJoin, table)
+}
+
+// StraightJoin forces MySQL to read the left table first.
+func (b *selectBuilderTable) StraightJoin(table *Table) *selectBuilderJoin {
+	return (*selectBuilderJoin)(b).join(straightJoin, table)
+}
+
+func (b *selectBuilderTable) CrossJoin(table *Table) *selectBuilderJoinSpec {
+	return (*selectBuilderJoinSpec)(b).join(crossJoin, table)
+}
+
+func (b *selectBuilderTable) NaturalJoin(table *Table) *selectBuilderJoinSpec {
+	return (*selectBuilderJoinSpec)(b).join(naturalJoin, table)
+}
+
+func (b *selectBuilderTable) LeftJoinLateral(sub *Subquery) *selectBuilderJoin {
+	return (*selectBuilderJoin)(b).joinLateral(leftJoin, sub)
+}
+
+func (b *selectBuilderTable) InnerJoinLateral(sub *Subquery) *selectBuilderJoin {
+	return (*selectBuilderJoin)(b).joinLateral(innerJoin, sub)
+}
+
+// CrossJoinLateral is rendered as CROSS APPLY on SQL Server.
+func (b *selectBuilderTable) CrossJoinLateral(sub *Subquery) *selectBuilderJoinSpec {
+	return (*selectBuilderJoinSpec)(b).joinLateral(crossJoin, sub)
+}
+
 func (b *selectBuilderTable) Where(conditions ...whereCondition) *selectBuilderWhere {
 	return (*selectBuilderWhere)(b).where(conditions)
 }
@@ -91,33 +160,41 @@ func (b *selectBuilderTable) Build() (string, []any) {
 }
 
 func (b *selectBuilderJoin) join(joinType Keyword, table *Table) *selectBuilderJoin {
-	b.buf.Space()
-	b.buf.WriteString(string(joinType))
-	b.buf.Space()
-	b.buf.Table(table)
+	(*SqlBuilder)(b).join(joinType, table)
+	return b
+}
+
+func (b *selectBuilderJoin) joinLateral(joinType Keyword, sub *Subquery) *selectBuilderJoin {
+	(*SqlBuilder)(b).joinLateral(joinType, sub)
 	return b
 }
 
 func (b *selectBuilderJoin) On(lhs, rhs *Field) *selectBuilderJoinSpec {
-	b.buf.Space()
-	b.buf.WriteString("ON")
-	b.buf.Space()
-	b.buf.Field(lhs)
-	b.buf.Equal()
-	b.buf.Field(rhs)
+	(*SqlBuilder)(b).on(lhs, rhs)
+	return (*selectBuilderJoinSpec)(b)
+}
+
+// OnCondition joins on arbitrary conditions, they are connected with AND.
+func (b *selectBuilderJoin) OnCondition(conditions ...whereCondition) *selectBuilderJoinSpec {
+	(*SqlBuilder)(b).onConditions(conditions)
 	return (*selectBuilderJoinSpec)(b)
 }
 
 func (b *selectBuilderJoin) Using(fields ...string) *selectBuilderJoinSpec {
-	b.buf.Space()
-	b.buf.WriteString("USING")
-	b.buf.Space()
-	b.buf.OpenParen()
-	b.buf.BackQuoteStrings(fields)
-	b.buf.CloseParen()
+	(*SqlBuilder)(b).using(fields)
 	return (*selectBuilderJoinSpec)(b)
 }
 
+func (b *selectBuilderJoinSpec) join(joinType Keyword, table *Table) *selectBuilderJoinSpec {
+	(*SqlBuilder)(b).join(joinType, table)
+	return b
+}
+
+func (b *selectBuilderJoinSpec) joinLateral(joinType Keyword, sub *Subquery) *selectBuilderJoinSpec {
+	(*SqlBuilder)(b).joinLateral(joinType, sub)
+	return b
+}
+
 func (b *selectBuilderJoinSpec) LeftJoin(table *Table) *selectBuilderJoin {
 	return (*selectBuilderJoin)(b).join(leftJoin, table)
 }
@@ -130,6 +207,41 @@ func (b *selectBuilderJoinSpec) InnerJoin(table *Table) *selectBuilderJoin {
 	return (*selectBuilderJoin)(b).join(innerJoin, table)
 }
 
+// FullJoin is not supported by MySQL.
+func (b *selectBuilderJoinSpec) FullJoin(table *Table) *selectBuilderJoin {
+	return (*selectBuilderJoin)(b).join(fullJoin, table)
+}
+
+// StraightJoin forces MySQL to read the left table first.
+func (b *selectBuilderJoinSpec) StraightJoin(table *Table) *selectBuilderJoin {
+	return (*selectBuilderJoin)(b).join(straightJoin, table)
+}
+
+func (b *selectBuilderJoinSpec) CrossJoin(table *Table) *selectBuilderJoinSpec {
+	return (*selectBuilderJoinSpec)(b).join(crossJoin, table)
+}
+
+func (b *selectBuilderJoinSpec) NaturalJoin(table *Table) *selectBuilderJoinSpec {
+	return (*selectBuilderJoinSpec)(b).join(naturalJoin, table)
+}
+
+func (b *selectBuilderJoinSpec) LeftJoinLateral(sub *Subquery) *selectBuilderJoin {
+	return (*selectBuilderJoin)(b).joinLateral(leftJoin, sub)
+}
+
+func (b *selectBuilderJoinSpec) InnerJoinLateral(sub *Subquery) *selectBuilderJoin {
+	return (*selectBuilderJoin)(b).joinLateral(innerJoin, sub)
+}
+
+// CrossJoinLateral is rendered as CROSS APPLY on SQL Server.
+func (b *selectBuilderJoinSpec) CrossJoinLateral(sub *Subquery) *selectBuilderJoinSpec {
+	return (*selectBuilderJoinSpec)(b).joinLateral(crossJoin, sub)
+}
+
+func (b *selectBuilderJoinSpec) Build() (string, []any) {
+	return (*sqlBuilderBuild)(b).Build()
+}
+
 func (b *selectBuilderJoinSpec) Where(conditions ...whereCondition) *selectBuilderWhere {
 	return (*selectBuilderWhere)(b).where(conditions)
 }
@@ -159,7 +271,7 @@ func (b *selectBuilderWhere) where(conditions []whereCondition) *selectBuilderWh
 	b.buf.Space()
 	b.buf.Conditions(conditions)
 	for _, c := range conditions {
-		b.args = append(b.args, c.args()...)
+		b.args = append(b.args, c.args(b.dialect)...)
 	}
 	return b
 }
@@ -188,7 +300,8 @@ func (b *selectBuilderGroup) groupBy(fields []any) *selectBuilderGroup {
 	b.buf.Space()
 	b.buf.WriteString("GROUP BY")
 	b.buf.Space()
-	b.buf.AnyFields(fields)
+	b.buf.Operands(fields)
+	b.args = append(b.args, fieldsArgs(b.dialect, fields)...)
 	return b
 }
 
@@ -196,6 +309,19 @@ func (b *selectBuilderGroup) Build() (string, []any) {
 	return (*sqlBuilderBuild)(b).Build()
 }
 
+// Having filters the groups, e.g. Having(Gt(Count(), 1)). The clause is omitted
+// without condition.
+func (b *selectBuilderGroup) Having(conditions ...whereCondition) *selectBuilderHaving {
+	if len(conditions) == 0 {
+		return (*selectBuilderHaving)(b)
+	}
+	b.buf.Space()
+	b.buf.WriteString("HAVING")
+	b.buf.Space()
+	(*SqlBuilder)(b).conditions(conditions)
+	return (*selectBuilderHaving)(b)
+}
+
 func (b *selectBuilderGroup) OrderBy(orderSpecs ...*OrderSpec) *selectBuilderOrder {
 	return (*selectBuilderOrder)(b).order(orderSpecs)
 }
@@ -208,11 +334,28 @@ func (b *selectBuilderGroup) LimitOffset(limit, offset any) *selectBuilderLimit
 	return (*selectBuilderLimit)(b).limit(limit, offset)
 }
 
+func (b *selectBuilderHaving) Build() (string, []any) {
+	return (*sqlBuilderBuild)(b).Build()
+}
+
+func (b *selectBuilderHaving) OrderBy(orderSpecs ...*OrderSpec) *selectBuilderOrder {
+	return (*selectBuilderOrder)(b).order(orderSpecs)
+}
+
+func (b *selectBuilderHaving) Limit(limit any) *selectBuilderLimit {
+	return (*selectBuilderLimit)(b).limit(limit)
+}
+
+func (b *selectBuilderHaving) LimitOffset(limit, offset any) *selectBuilderLimit {
+	return (*selectBuilderLimit)(b).limit(limit, offset)
+}
+
 func (b *selectBuilderOrder) order(orderSpecs []*OrderSpec) *selectBuilderOrder {
 	b.buf.Space()
 	b.buf.WriteString("ORDER BY")
 	b.buf.Space()
 	b.buf.OrderSpecs(orderSpecs)
+	b.args = append(b.args, orderArgs(b.dialect, orderSpecs)...)
 	return b
 }
 
@@ -1,8 +1,31 @@
 package sqlbuilder
 
 type SqlBuilder struct {
-	buf  *buffer
-	args []any
+	buf     *buffer
+	args    []any
+	dialect Dialect
+	err     error
+
+	// target is the table a multi-table UPDATE or DELETE writes to.
+	target   *Table
+	targets  []string
+	from     *fromList
+	hasWhere bool
+
+	// columns are the columns of an INSERT.
+	columns []string
+	// outputMark is where SQL Server expects the OUTPUT clause, -1 if unknown,
+	// outputArgMark is the number of args written before it.
+	outputMark    int
+	outputArgMark int
+
+	upsert *upsertClause
+	chunks *bulkChunks
+	// hasConflictTarget tells whether ON CONFLICT names the conflicting index.
+	hasConflictTarget bool
+
+	// selected is the number of fields of a SELECT, 0 if it is unknown.
+	selected int
 }
 
 func New() *SqlBuilder {
@@ -11,8 +34,139 @@ func New() *SqlBuilder {
 	}
 }
 
+// Dialect sets the database the statement is rendered for, the default is MySQL.
+func (b *SqlBuilder) Dialect(d Dialect) *SqlBuilder {
+	b.dialect = d
+	return b
+}
+
+// Err returns the error met by the last Build, a failed Build returns an empty statement.
+func (b *SqlBuilder) Err() error {
+	return b.err
+}
+
 func (b *SqlBuilder) init() {
 	b.buf = getBuffer()
+	b.buf.dialect = b.dialect
+	b.err = nil
+	b.target = nil
+	b.targets = nil
+	b.from = nil
+	b.hasWhere = false
+	b.columns = nil
+	b.outputMark = -1
+	b.outputArgMark = 0
+	b.upsert = nil
+	b.chunks = nil
+	b.hasConflictTarget = false
+	b.selected = 0
+}
+
+// where writes the conditions, continuing the WHERE clause if it has been opened.
+func (b *SqlBuilder) where(conditions []whereCondition) {
+	if len(conditions) == 0 {
+		return
+	}
+	if !b.hasWhere {
+		b.whereClause(conditions)
+		b.hasWhere = true
+		return
+	}
+	b.buf.Space()
+	b.buf.WriteString(string(AndOperator))
+	b.buf.Space()
+	b.conditions(conditions)
+}
+
+// whereClause writes a WHERE clause of its own, e.g. the one of ON CONFLICT.
+func (b *SqlBuilder) whereClause(conditions []whereCondition) {
+	if len(conditions) == 0 {
+		return
+	}
+	b.buf.Space()
+	b.buf.WriteString("WHERE")
+	b.buf.Space()
+	b.conditions(conditions)
+}
+
+// returning writes the RETURNING clause of stmt, or the OUTPUT clause on SQL Server.
+func (b *SqlBuilder) returning(stmt string, fields []any) {
+	if b.dialect == SQLServer && b.outputMark >= 0 {
+		prefix := "INSERTED"
+		if stmt == "DELETE" {
+			prefix = "DELETED"
+		}
+		b.insertAt(b.outputMark, func() {
+			b.buf.Space()
+			b.buf.WriteString("OUTPUT")
+			b.buf.Space()
+			if len(fields) == 0 {
+				b.buf.WriteString(prefix)
+				b.buf.WriteString(".*")
+			}
+			for i, f := range fields {
+				if i > 0 {
+					b.buf.Comma()
+				}
+				b.output(prefix, f)
+			}
+		})
+		args := fieldsArgs(b.dialect, fields)
+		tail := append([]any(nil), b.args[b.outputArgMark:]...)
+		b.args = append(append(b.args[:b.outputArgMark], args...), tail...)
+		return
+	}
+	if !b.dialect.supportsReturning(stmt) {
+		b.buf.setErr(unsupported(b.dialect, stmt+" ... RETURNING"))
+	}
+	b.buf.Space()
+	b.buf.WriteString("RETURNING")
+	b.buf.Space()
+	if len(fields) == 0 {
+		b.buf.WriteByte('*')
+	} else {
+		b.buf.AnyFields(fields)
+		b.args = append(b.args, fieldsArgs(b.dialect, fields)...)
+	}
+}
+
+// output writes a field of the OUTPUT clause, a column is qualified by prefix,
+// INSERTED or DELETED, instead of its table.
+func (b *SqlBuilder) output(prefix string, field any) {
+	switch v := field.(type) {
+	case string:
+		b.buf.WriteString(prefix)
+		b.buf.Dot()
+		b.buf.Quote(v)
+	case *Field:
+		b.buf.WriteString(prefix)
+		b.buf.Dot()
+		b.buf.Quote(v.Field)
+		b.buf.Alias(v.Alias)
+	default:
+		b.buf.AnyField(field)
+	}
+}
+
+// markOutput records where SQL Server expects the OUTPUT clause.
+func (b *SqlBuilder) markOutput() {
+	b.outputMark = b.buf.Len()
+	b.outputArgMark = len(b.args)
+}
+
+// insertAt writes what fn writes at the position mark instead of at the end.
+func (b *SqlBuilder) insertAt(mark int, fn func()) {
+	tail := string(b.buf.Bytes()[mark:])
+	b.buf.Truncate(mark)
+	fn()
+	b.buf.WriteString(tail)
+}
+
+func (b *SqlBuilder) conditions(conditions []whereCondition) {
+	b.buf.Conditions(conditions)
+	for i := range conditions {
+		b.args = append(b.args, conditions[i].args(b.dialect)...)
+	}
 }
 
 func (b *SqlBuilder) Insert(kws ...Keyword) *insertBuilder {
@@ -20,6 +174,17 @@ func (b *SqlBuilder) Insert(kws ...Keyword) *insertBuilder {
 	return (*insertBuilder)(b).init(kws)
 }
 
+func (b *SqlBuilder) Replace(kws ...Keyword) *replaceBuilder {
+	b.init()
+	return (*replaceBuilder)(b).init(kws)
+}
+
+// Upsert inserts rows or updates them on a key conflict, rendered with the syntax of the dialect.
+func (b *SqlBuilder) Upsert() *upsertBuilder {
+	b.init()
+	return (*upsertBuilder)(b).init()
+}
+
 func (b *SqlBuilder) Select(kws ...Keyword) *selectBuilder {
 	b.init()
 	return (*selectBuilder)(b).init(kws)
@@ -38,6 +203,11 @@ func (b *SqlBuilder) Update(kws ...Keyword) *updateBuilder {
 type sqlBuilderBuild SqlBuilder
 
 func (b *sqlBuilderBuild) Build() (string, []any) {
+	if b.buf.err != nil {
+		b.err = b.buf.err
+		releaseBuffer(b.buf)
+		return "", nil
+	}
 	sql := b.buf.String()
 	releaseBuffer(b.buf)
 	return sql, b.args
@@ -0,0 +1,242 @@
+package sqlbuilder
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const tagName = "db"
+
+type structOptions struct {
+	omitZero bool
+	include  map[string]bool
+	exclude  map[string]bool
+}
+
+// StructOption selects the columns read from a struct.
+type StructOption func(*structOptions)
+
+// OmitZero skips the fields holding the zero value of their type.
+func OmitZero() StructOption {
+	return func(o *structOptions) {
+		o.omitZero = true
+	}
+}
+
+// Include only uses the given columns.
+func Include(columns ...string) StructOption {
+	return func(o *structOptions) {
+		if o.include == nil {
+			o.include = make(map[string]bool, len(columns))
+		}
+		for _, c := range columns {
+			o.include[c] = true
+		}
+	}
+}
+
+// Exclude skips the given columns.
+func Exclude(columns ...string) StructOption {
+	return func(o *structOptions) {
+		if o.exclude == nil {
+			o.exclude = make(map[string]bool, len(columns))
+		}
+		for _, c := range columns {
+			o.exclude[c] = true
+		}
+	}
+}
+
+type structField struct {
+	column    string
+	index     []int
+	typ       reflect.Type
+	omitEmpty bool
+	auto      bool
+}
+
+var structFieldsCache sync.Map
+
+// cachedStructFields returns the fields of the struct type t, the result is cached per type.
+func cachedStructFields(t reflect.Type) []structField {
+	if v, ok := structFieldsCache.Load(t); ok {
+		return v.([]structField)
+	}
+	v, _ := structFieldsCache.LoadOrStore(t, structFields(t, nil))
+	return v.([]structField)
+}
+
+// structFields returns the fields tagged with `db:"column[,omitempty][,auto]"` in
+// declaration order, fields without the tag or tagged with `db:"-"` are skipped.
+// The fields of an untagged embedded struct are read as if they were declared in t.
+func structFields(t reflect.Type, parent []int) []structField {
+	fields := make([]structField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		index := make([]int, len(parent)+1)
+		copy(index, parent)
+		index[len(parent)] = i
+		tag := f.Tag.Get(tagName)
+		if f.Anonymous && tag == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, structFields(ft, index)...)
+				continue
+			}
+		}
+		if f.PkgPath != "" || tag == "" || tag == "-" {
+			continue
+		}
+		opts := strings.Split(tag, ",")
+		field := structField{column: opts[0], index: index, typ: f.Type}
+		for _, opt := range opts[1:] {
+			switch opt {
+			case "omitempty":
+				field.omitEmpty = true
+			case "auto":
+				field.auto = true
+			}
+		}
+		fields = append(fields, field)
+	}
+	return fields
+}
+
+// value is reflect.Value.FieldByIndex returning the zero value instead of
+// panicking on a nil embedded pointer.
+func (f *structField) value(v reflect.Value) reflect.Value {
+	for i, x := range f.index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Zero(f.typ)
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// structValue dereferences v and checks that it is a struct.
+func structValue(v any) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return rv, fmt.Errorf("%w: expect a struct, got %T", ErrInvalidColumn, v)
+	}
+	return rv, nil
+}
+
+// structColumns returns the columns and values of the struct selected by opts.
+func structColumns(v any, opts []StructOption) ([]string, []any, error) {
+	rv, err := structValue(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	o := &structOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	fields := cachedStructFields(rv.Type())
+	columns := make([]string, 0, len(fields))
+	values := make([]any, 0, len(fields))
+	for i := range fields {
+		f := &fields[i]
+		if (o.include != nil && !o.include[f.column]) || o.exclude[f.column] {
+			continue
+		}
+		fv := f.value(rv)
+		if (o.omitZero || f.omitEmpty) && fv.IsZero() {
+			continue
+		}
+		columns = append(columns, f.column)
+		values = append(values, fv.Interface())
+	}
+	return columns, values, nil
+}
+
+// structRows reads rows, which is a struct, a pointer to struct or a slice of them.
+// It returns the fields to insert and a function reading the values of the i-th row.
+// auto fields are skipped, omitempty fields are skipped when they are zero in every row.
+func structRows(rows any) (int, []string, func(int) []any, error) {
+	rv := reflect.ValueOf(rows)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() && rv.Elem().Kind() != reflect.Struct {
+		rv = rv.Elem()
+	}
+	row := func(i int) reflect.Value {
+		return rv
+	}
+	n := 1
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		n = rv.Len()
+		row = func(i int) reflect.Value {
+			return rv.Index(i)
+		}
+	}
+	if n == 0 {
+		return 0, nil, nil, ErrNoRows
+	}
+	first, err := structValue(row(0).Interface())
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	rowType := first.Type()
+	values := make([]reflect.Value, n)
+	for i := 0; i < n; i++ {
+		v, err := structValue(row(i).Interface())
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		if v.Type() != rowType {
+			return 0, nil, nil, fmt.Errorf("%w: expect rows of %v, got %v", ErrInvalidColumn, rowType, v.Type())
+		}
+		values[i] = v
+	}
+
+	all := cachedStructFields(rowType)
+	fields := make([]*structField, 0, len(all))
+	columns := make([]string, 0, len(all))
+	for i := range all {
+		f := &all[i]
+		if f.auto || (f.omitEmpty && allZero(f, values)) {
+			continue
+		}
+		fields = append(fields, f)
+		columns = append(columns, f.column)
+	}
+	argf := func(i int) []any {
+		args := make([]any, len(fields))
+		for j, f := range fields {
+			args[j] = f.value(values[i]).Interface()
+		}
+		return args
+	}
+	return n, columns, argf, nil
+}
+
+func allZero(f *structField, values []reflect.Value) bool {
+	for _, v := range values {
+		if !f.value(v).IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedKeys returns the keys of m in ascending order, so that the generated SQL is stable.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,6 +1,7 @@
 package tests
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -10,6 +11,41 @@ import (
 	sb "github.com/llklkl/sqlbuilder"
 )
 
+var errBadJSON = errors.New("bad json")
+
+// badJSON cannot be encoded as JSON.
+type badJSON struct{}
+
+func (badJSON) MarshalJSON() ([]byte, error) {
+	return nil, errBadJSON
+}
+
+type student struct {
+	ID      int64  `db:"id"`
+	Name    string `db:"name"`
+	Age     int    `db:"age"`
+	Class   string `db:"class"`
+	Comment string `db:"-"`
+	score   int
+}
+
+type timestamps struct {
+	CreatedAt int64 `db:"created_at"`
+	UpdatedAt int64 `db:"updated_at,omitempty"`
+}
+
+type operator struct {
+	Operator string `db:"operator"`
+}
+
+type order struct {
+	ID     int64  `db:"id,auto"`
+	UserID int64  `db:"user_id"`
+	Remark string `db:"remark,omitempty"`
+	timestamps
+	*operator
+}
+
 func sqlCheck(t *testing.T, sql string) {
 	parse := parser.New()
 	_, _, err := parse.Parse(sql, "", "")
@@ -67,6 +103,94 @@ func TestSqlBuilder_Insert(t *testing.T) {
 			wantSql:  "INSERT INTO `demo` (`name`,`age`) VALUES (?,?),(?,?),(?,?) ON DUPLICATE KEY UPDATE `name`=?,`age`=`age`+1",
 			wantArgs: []any{"alice", 19, "bob", 20, "carol", 21, "duplicate"},
 		},
+		{
+			name: "insert structs",
+			workFn: func() (string, []any) {
+				orders := []*order{
+					{ID: 1, UserID: 10, timestamps: timestamps{CreatedAt: 100}, operator: &operator{Operator: "alice"}},
+					{UserID: 11, Remark: "gift", timestamps: timestamps{CreatedAt: 101}},
+				}
+				return sb.New().Insert().Into("orders").Structs(orders).Build()
+			},
+			wantSql:  "INSERT INTO `orders` (`user_id`,`remark`,`created_at`,`operator`) VALUES (?,?,?,?),(?,?,?,?)",
+			wantArgs: []any{int64(10), "", int64(100), "alice", int64(11), "gift", int64(101), ""},
+		},
+		{
+			name: "insert a struct",
+			workFn: func() (string, []any) {
+				return sb.New().Insert().Into("demo").
+					Structs(student{ID: 1, Name: "alice", Age: 20}).Build()
+			},
+			wantSql:  "INSERT INTO `demo` (`id`,`name`,`age`,`class`) VALUES (?,?,?,?)",
+			wantArgs: []any{int64(1), "alice", 20, ""},
+		},
+		{
+			name: "insert pairs",
+			workFn: func() (string, []any) {
+				return sb.New().Insert().Into("demo").
+					Pairs(
+						sb.Set("name", "alice"),
+						sb.Set(sb.F("age"), 20),
+					).Build()
+			},
+			wantSql:  "INSERT INTO `demo` (`name`,`age`) VALUES (?,?)",
+			wantArgs: []any{"alice", 20},
+		},
+		{
+			name: "insert pair map",
+			workFn: func() (string, []any) {
+				return sb.New().Insert().Into("demo").
+					PairMap(map[string]any{"name": "alice", "age": 20}).Build()
+			},
+			wantSql:  "INSERT INTO `demo` (`age`,`name`) VALUES (?,?)",
+			wantArgs: []any{20, "alice"},
+		},
+		{
+			name: "insert ... set",
+			workFn: func() (string, []any) {
+				return sb.New().Insert().Into("demo").
+					Set(
+						sb.Set("name", "alice"),
+						sb.Value("`age`=?", 20),
+					).
+					OnDuplicate(sb.Set("name", "bob")).Build()
+			},
+			wantSql:  "INSERT INTO `demo` SET `name`=?,`age`=? ON DUPLICATE KEY UPDATE `name`=?",
+			wantArgs: []any{"alice", 20, "bob"},
+		},
+		{
+			name: "insert ... set map",
+			workFn: func() (string, []any) {
+				return sb.New().Insert().Into("demo").
+					SetMap(map[string]any{"name": "alice", "age": 20}).Build()
+			},
+			wantSql:  "INSERT INTO `demo` SET `age`=?,`name`=?",
+			wantArgs: []any{20, "alice"},
+		},
+		{
+			name: "insert, duplicate from inserted row",
+			workFn: func() (string, []any) {
+				return sb.New().Insert().Into("demo").
+					Fields("id", "name", "age").
+					Values(1, "alice", 20).
+					OnDuplicate(
+						sb.UpdateFromInsert("name", "age"),
+						sb.Set("updated", sb.Excluded("age")),
+					).Build()
+			},
+			wantSql:  "INSERT INTO `demo` (`id`,`name`,`age`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `name`=VALUES(`name`),`age`=VALUES(`age`),`updated`=VALUES(`age`)",
+			wantArgs: []any{1, "alice", 20},
+		},
+		{
+			name: "insert, duplicate update all",
+			workFn: func() (string, []any) {
+				return sb.New().Insert().Into("demo").
+					Structs(&student{ID: 1, Name: "alice", Age: 20}).
+					OnDuplicateUpdateAll("id").Build()
+			},
+			wantSql:  "INSERT INTO `demo` (`id`,`name`,`age`,`class`) VALUES (?,?,?,?) ON DUPLICATE KEY UPDATE `name`=VALUES(`name`),`age`=VALUES(`age`),`class`=VALUES(`class`)",
+			wantArgs: []any{int64(1), "alice", 20, ""},
+		},
 		{
 			name: "insert ... select",
 			workFn: func() (string, []any) {
@@ -75,6 +199,70 @@ func TestSqlBuilder_Insert(t *testing.T) {
 			wantSql:  "INSERT IGNORE INTO `demo` SELECT * FROM `demo2` WHERE `id` > ?",
 			wantArgs: []any{100},
 		},
+		{
+			name: "insert on duplicate with set helpers",
+			workFn: func() (string, []any) {
+				return sb.New().Insert().Into("counter").
+					Fields("id", "hits", "last").
+					Values(1, 1, "a").
+					OnDuplicate(sb.Incr("hits", 1), sb.SetColumn("last", "id")).Build()
+			},
+			wantSql:  "INSERT INTO `counter` (`id`,`hits`,`last`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `hits`=`hits`+?,`last`=`id`",
+			wantArgs: []any{1, 1, "a", 1},
+		},
+		{
+			name: "insert expression values",
+			workFn: func() (string, []any) {
+				return sb.New().Insert().Into("demo").
+					Fields("id", "uuid", "name", "created_at").
+					Values(sb.Default, sb.Raw("UUID_TO_BIN(?)", "6ccd780c"), "alice", sb.Raw("NOW()")).Build()
+			},
+			wantSql:  "INSERT INTO `demo` (`id`,`uuid`,`name`,`created_at`) VALUES (DEFAULT,UUID_TO_BIN(?),?,NOW())",
+			wantArgs: []any{"6ccd780c", "alice"},
+		},
+		{
+			name: "bulk insert expression values",
+			workFn: func() (string, []any) {
+				return sb.New().Insert().Into("place").
+					Fields("name", "location").
+					Bulk(2, func(index int) []any {
+						return []any{"p", sb.Raw("POINT(?,?)", index, index+1)}
+					}).Build()
+			},
+			wantSql:  "INSERT INTO `place` (`name`,`location`) VALUES (?,POINT(?,?)),(?,POINT(?,?))",
+			wantArgs: []any{"p", 0, 1, "p", 1, 2},
+		},
+		{
+			name: "insert default values",
+			workFn: func() (string, []any) {
+				return sb.New().Insert().Into("demo").DefaultValues().Build()
+			},
+			wantSql:  "INSERT INTO `demo` () VALUES ()",
+			wantArgs: nil,
+		},
+		{
+			name: "insert ... select chain",
+			workFn: func() (string, []any) {
+				return sb.New().Insert().Into("demo").
+					Fields("name", "age").
+					Select(sb.New().Select().Field("name", sb.F("u", "age")).
+						FromT(sb.T("user", "u")).
+						Where(sb.Gt(sb.F("u", "id"), 100))).Build()
+			},
+			wantSql:  "INSERT INTO `demo` (`name`,`age`) SELECT `name`,`u`.`age` FROM `user` AS `u` WHERE `u`.`id` > ?",
+			wantArgs: []any{100},
+		},
+		{
+			name: "insert ... select of a named string type",
+			workFn: func() (string, []any) {
+				type query string
+				return sb.New().Insert().Into("demo").
+					Fields("name", "age").
+					Select(query("SELECT `name`,`age` FROM `user` WHERE `id` > ?"), 100).Build()
+			},
+			wantSql:  "INSERT INTO `demo` (`name`,`age`) SELECT `name`,`age` FROM `user` WHERE `id` > ?",
+			wantArgs: []any{100},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -90,6 +278,231 @@ func TestSqlBuilder_Insert(t *testing.T) {
 	}
 }
 
+func TestSqlBuilder_BulkChunks(t *testing.T) {
+	names := []string{"alice", "bob", "carol", "dave", "eve"}
+	argf := func(index int) []any {
+		return []any{names[index], 20 + index}
+	}
+	tests := []struct {
+		name    string
+		workFn  func() ([]sb.Statement, error)
+		want    []sb.Statement
+		wantErr bool
+		// wantErrIs is the error expected when wantErr is set, if not nil.
+		wantErrIs error
+	}{
+		{
+			name: "max rows",
+			workFn: func() ([]sb.Statement, error) {
+				b := sb.New()
+				statements := b.Insert().Into("demo").
+					Fields("name", "age").
+					BulkChunks(len(names), argf, sb.ChunkLimit{MaxRows: 2}).Build()
+				return statements, b.Err()
+			},
+			want: []sb.Statement{
+				{Sql: "INSERT INTO `demo` (`name`,`age`) VALUES (?,?),(?,?)", Args: []any{"alice", 20, "bob", 21}},
+				{Sql: "INSERT INTO `demo` (`name`,`age`) VALUES (?,?),(?,?)", Args: []any{"carol", 22, "dave", 23}},
+				{Sql: "INSERT INTO `demo` (`name`,`age`) VALUES (?,?)", Args: []any{"eve", 24}},
+			},
+		},
+		{
+			name: "max placeholders with on duplicate",
+			workFn: func() ([]sb.Statement, error) {
+				b := sb.New()
+				statements := b.Insert().Into("demo").
+					Fields("name", "age").
+					BulkChunks(len(names), argf, sb.ChunkLimit{MaxPlaceholders: 7}).
+					OnDuplicate(sb.Set("age", 0)).Build()
+				return statements, b.Err()
+			},
+			want: []sb.Statement{
+				{Sql: "INSERT INTO `demo` (`name`,`age`) VALUES (?,?),(?,?),(?,?) ON DUPLICATE KEY UPDATE `age`=?", Args: []any{"alice", 20, "bob", 21, "carol", 22, 0}},
+				{Sql: "INSERT INTO `demo` (`name`,`age`) VALUES (?,?),(?,?) ON DUPLICATE KEY UPDATE `age`=?", Args: []any{"dave", 23, "eve", 24, 0}},
+			},
+		},
+		{
+			name: "max bytes",
+			workFn: func() ([]sb.Statement, error) {
+				b := sb.New()
+				statements := b.Insert().Into("demo").
+					Fields("name", "age").
+					BulkChunks(len(names), argf, sb.ChunkLimit{MaxBytes: 110}).
+					OnDuplicateUpdateAll("name").Build()
+				return statements, b.Err()
+			},
+			want: []sb.Statement{
+				{Sql: "INSERT INTO `demo` (`name`,`age`) VALUES (?,?),(?,?) ON DUPLICATE KEY UPDATE `age`=VALUES(`age`)", Args: []any{"alice", 20, "bob", 21}},
+				{Sql: "INSERT INTO `demo` (`name`,`age`) VALUES (?,?),(?,?) ON DUPLICATE KEY UPDATE `age`=VALUES(`age`)", Args: []any{"carol", 22, "dave", 23}},
+				{Sql: "INSERT INTO `demo` (`name`,`age`) VALUES (?,?) ON DUPLICATE KEY UPDATE `age`=VALUES(`age`)", Args: []any{"eve", 24}},
+			},
+		},
+		{
+			name: "a row exceeds the limit",
+			workFn: func() ([]sb.Statement, error) {
+				b := sb.New()
+				statements := b.Insert().Into("demo").
+					Fields("name", "age").
+					BulkChunks(len(names), argf, sb.ChunkLimit{MaxPlaceholders: 1}).Build()
+				return statements, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrChunkLimit,
+		},
+		{
+			name: "a row of the wrong width",
+			workFn: func() ([]sb.Statement, error) {
+				b := sb.New()
+				statements := b.Insert().Into("demo").
+					Fields("name", "age").
+					BulkChunks(len(names), func(index int) []any {
+						if index == 2 {
+							return []any{names[index]}
+						}
+						return argf(index)
+					}, sb.ChunkLimit{MaxRows: 2}).Build()
+				return statements, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrColumnCount,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements, err := tt.workFn()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BulkChunks err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("BulkChunks err = %v, want %v", err, tt.wantErrIs)
+			}
+			if !reflect.DeepEqual(statements, tt.want) {
+				t.Errorf("BulkChunks got = %v, want %v", statements, tt.want)
+			}
+			for _, s := range statements {
+				sqlCheck(t, s.Sql)
+			}
+		})
+	}
+}
+
+func TestSqlBuilder_Replace(t *testing.T) {
+	tests := []struct {
+		name     string
+		workFn   func() (string, []any)
+		wantSql  string
+		wantArgs []any
+	}{
+		{
+			name: "replace",
+			workFn: func() (string, []any) {
+				return sb.New().Replace().Into("config").
+					Fields("key", "value").
+					Values("theme", "dark").Build()
+			},
+			wantSql:  "REPLACE INTO `config` (`key`,`value`) VALUES (?,?)",
+			wantArgs: []any{"theme", "dark"},
+		},
+		{
+			name: "bulk replace",
+			workFn: func() (string, []any) {
+				keys := []string{"theme", "lang"}
+				values := []string{"dark", "en"}
+				return sb.New().Replace().IntoT(sb.T("config")).
+					Fields("key", "value").
+					Bulk(len(keys), func(index int) []any {
+						return []any{keys[index], values[index]}
+					}).Build()
+			},
+			wantSql:  "REPLACE INTO `config` (`key`,`value`) VALUES (?,?),(?,?)",
+			wantArgs: []any{"theme", "dark", "lang", "en"},
+		},
+		{
+			name: "replace ... select",
+			workFn: func() (string, []any) {
+				return sb.New().Replace().Into("config").
+					Fields("key", "value").
+					Select("SELECT `key`,`value` FROM `default_config` WHERE `id` > ?", 100).Build()
+			},
+			wantSql:  "REPLACE INTO `config` (`key`,`value`) SELECT `key`,`value` FROM `default_config` WHERE `id` > ?",
+			wantArgs: []any{100},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.workFn()
+			if sql != tt.wantSql {
+				t.Errorf("Replace sql got = %v, want %v", sql, tt.wantSql)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("Replace args got1 = %v, want %v", args, tt.wantArgs)
+			}
+			sqlCheck(t, sql)
+		})
+	}
+}
+
+func TestSqlBuilder_Upsert(t *testing.T) {
+	tests := []struct {
+		name     string
+		workFn   func() (string, []any)
+		wantSql  string
+		wantArgs []any
+	}{
+		{
+			name: "upsert",
+			workFn: func() (string, []any) {
+				return sb.New().Upsert().Into("config").
+					Fields("key", "value").
+					Values("theme", "dark").
+					ConflictOn("key").
+					Update().Build()
+			},
+			wantSql:  "INSERT INTO `config` (`key`,`value`) VALUES (?,?) ON DUPLICATE KEY UPDATE `value`=VALUES(`value`)",
+			wantArgs: []any{"theme", "dark"},
+		},
+		{
+			name: "upsert expression values",
+			workFn: func() (string, []any) {
+				return sb.New().Upsert().Into("config").
+					Fields("key", "value", "updated_at").
+					Values("theme", sb.Raw("LOWER(?)", "Dark"), sb.Default).
+					ConflictOn("key").
+					Update().Build()
+			},
+			wantSql: "INSERT INTO `config` (`key`,`value`,`updated_at`) VALUES (?,LOWER(?),DEFAULT)" +
+				" ON DUPLICATE KEY UPDATE `value`=VALUES(`value`),`updated_at`=VALUES(`updated_at`)",
+			wantArgs: []any{"theme", "Dark"},
+		},
+		{
+			name: "bulk upsert of the given columns",
+			workFn: func() (string, []any) {
+				return sb.New().Upsert().IntoT(sb.T("user")).
+					Fields("id", "name", "age").
+					Bulk(2, func(index int) []any {
+						return []any{index + 1, "alice", 20}
+					}).
+					ConflictOn("id").
+					Update("age").Build()
+			},
+			wantSql:  "INSERT INTO `user` (`id`,`name`,`age`) VALUES (?,?,?),(?,?,?) ON DUPLICATE KEY UPDATE `age`=VALUES(`age`)",
+			wantArgs: []any{1, "alice", 20, 2, "alice", 20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.workFn()
+			if sql != tt.wantSql {
+				t.Errorf("Upsert sql got = %v, want %v", sql, tt.wantSql)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("Upsert args got1 = %v, want %v", args, tt.wantArgs)
+			}
+			sqlCheck(t, sql)
+		})
+	}
+}
+
 func TestSqlBuilder_Select(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -326,74 +739,329 @@ func TestSqlBuilder_Select(t *testing.T) {
 			wantSql:  "SELECT `id`,`name`,`price` FROM `products` LEFT JOIN `shop` ON `shop_id`=`shop_id` INNER JOIN `product_price` USING (`product_id`) WHERE `price` >= ? ORDER BY `name` ASC LIMIT ?,?",
 			wantArgs: []any{100, 10, 5},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			sql, args := tt.workFn()
-			if sql != tt.wantSql {
-				t.Errorf("Select sql got = %v, want %v", sql, tt.wantSql)
-			}
-			if !reflect.DeepEqual(args, tt.wantArgs) {
-				t.Errorf("SELECT args got1 = %v, want %v", args, tt.wantArgs)
-			}
-			sqlCheck(t, sql)
-		})
-	}
-}
-
-func TestSqlBuilder_Delete(t *testing.T) {
-	tests := []struct {
-		name     string
-		workFn   func() (string, []any)
-		wantSql  string
-		wantArgs []any
-	}{
 		{
-			name: "DELETE",
+			name: "select, cross join and natural join",
 			workFn: func() (string, []any) {
-				return sb.New().Delete().From("demo").
-					Where(sb.Ge(sb.F("age"), 20)).
-					Order(sb.O(sb.F("name"), sb.Desc)).
-					Limit(10).Build()
+				return sb.New().Select().Field().
+					FromT(sb.T("products", "p")).
+					CrossJoin(sb.T("shop", "s")).
+					NaturalJoin(sb.T("product_price")).
+					Where(sb.Ge(sb.F("price"), 100)).Build()
 			},
-			wantSql:  "DELETE FROM `demo` WHERE `age` >= ? ORDER BY `name` DESC LIMIT ?",
-			wantArgs: []any{20, 10},
+			wantSql:  "SELECT * FROM `products` AS `p` CROSS JOIN `shop` AS `s` NATURAL JOIN `product_price` WHERE `price` >= ?",
+			wantArgs: []any{100},
 		},
 		{
-			name: "DELETE",
+			name: "select, straight join",
 			workFn: func() (string, []any) {
-				return sb.New().Delete().From("demo").
-					Where(sb.Ge(sb.F("age"), 20)).
-					Limit(10).Build()
+				return sb.New().Select().Field().
+					FromT(sb.T("products", "p")).
+					StraightJoin(sb.T("shop", "s")).On(sb.F("p", "shop_id"), sb.F("s", "id")).
+					CrossJoin(sb.T("product_price")).Build()
 			},
-			wantSql:  "DELETE FROM `demo` WHERE `age` >= ? LIMIT ?",
-			wantArgs: []any{20, 10},
+			wantSql:  "SELECT * FROM `products` AS `p` STRAIGHT_JOIN `shop` AS `s` ON `p`.`shop_id`=`s`.`id` CROSS JOIN `product_price`",
+			wantArgs: nil,
 		},
 		{
-			name: "DELETE",
+			name: "select, join on conditions",
 			workFn: func() (string, []any) {
-				return sb.New().Delete().From("demo").
-					Where(sb.Ge(sb.F("age"), 20)).Build()
+				return sb.New().Select().Field().
+					FromT(sb.T("products", "p")).
+					LeftJoin(sb.T("shop", "s")).
+					OnCondition(
+						sb.Condition("`p`.`shop_id`=`s`.`id`"),
+						sb.Eq(sb.F("s", "status"), 1),
+					).Build()
 			},
-			wantSql:  "DELETE FROM `demo` WHERE `age` >= ?",
-			wantArgs: []any{20},
+			wantSql:  "SELECT * FROM `products` AS `p` LEFT JOIN `shop` AS `s` ON `p`.`shop_id`=`s`.`id` AND `s`.`status` = ?",
+			wantArgs: []any{1},
 		},
 		{
-			name: "DELETE, without where",
+			name: "parameterized expressions and function calls",
 			workFn: func() (string, []any) {
-				return sb.New().Delete().From("demo").
-					Order(sb.O(sb.F("name"), sb.Desc)).
-					Limit(10).Build()
+				month := sb.Fn("DATE_FORMAT", sb.F("created"), "%Y-%m")
+				return sb.New().Select().
+					Field(
+						sb.Fn("DATE_FORMAT", sb.F("created"), "%Y-%m").As("month"),
+						sb.Raw("SUM(IF(score > ?, 1, 0))", 60).As("passed"),
+					).
+					From("exam").
+					Where(sb.Eq(sb.Fn("COALESCE", sb.F("deleted"), 0), 0)).
+					GroupBy(month).
+					OrderBy(sb.O(month, sb.Desc)).Build()
 			},
-			wantSql:  "DELETE FROM `demo` ORDER BY `name` DESC LIMIT ?",
-			wantArgs: []any{10},
+			wantSql: "SELECT DATE_FORMAT(`created`,?) AS `month`,SUM(IF(score > ?, 1, 0)) AS `passed` FROM `exam`" +
+				" WHERE COALESCE(`deleted`,?) = ? GROUP BY DATE_FORMAT(`created`,?) ORDER BY DATE_FORMAT(`created`,?) DESC",
+			wantArgs: []any{"%Y-%m", 60, 0, 0, "%Y-%m", "%Y-%m"},
 		},
 		{
-			name: "DELETE, without where",
+			name: "aggregates with having",
 			workFn: func() (string, []any) {
-				return sb.New().Delete().From("demo").
-					Order(sb.O(sb.F("name"), sb.Desc)).
-					Build()
+				return sb.New().Select().
+					Field(
+						sb.F("d", "shop_id"),
+						sb.Count().As("total"),
+						sb.CountDistinct(sb.F("d", "user_id")).As("users"),
+						sb.Sum(sb.F("d", "price")).Filter(sb.Eq(sb.F("d", "status"), 1)).As("paid"),
+						sb.GroupConcat(sb.F("d", "name")).Distinct().OrderBy(sb.O(sb.F("d", "name"), sb.Asc)).Separator("; ").As("names"),
+						sb.JSONArrayAgg(sb.F("d", "id")).As("ids"),
+					).
+					FromT(sb.T("deal", "d")).
+					GroupBy(sb.F("d", "shop_id")).
+					Having(sb.Gt(sb.Avg(sb.F("d", "price")), 10), sb.Le(sb.Max(sb.F("d", "price")), 1000)).
+					OrderBy(sb.O(sb.Min(sb.F("d", "price")), sb.Desc)).Build()
+			},
+			wantSql: "SELECT `d`.`shop_id`,COUNT(*) AS `total`,COUNT(DISTINCT `d`.`user_id`) AS `users`," +
+				"SUM(CASE WHEN `d`.`status` = ? THEN `d`.`price` END) AS `paid`," +
+				"GROUP_CONCAT(DISTINCT `d`.`name` ORDER BY `d`.`name` ASC SEPARATOR '; ') AS `names`," +
+				"JSON_ARRAYAGG(`d`.`id`) AS `ids` FROM `deal` AS `d` GROUP BY `d`.`shop_id`" +
+				" HAVING AVG(`d`.`price`) > ? AND MAX(`d`.`price`) <= ? ORDER BY MIN(`d`.`price`) DESC",
+			wantArgs: []any{1, 10, 1000},
+		},
+		{
+			name: "comparison operators",
+			workFn: func() (string, []any) {
+				return sb.New().Select().Field().
+					From("user").
+					Where(
+						sb.NotLike(sb.F("name"), "test%"),
+						sb.ILike(sb.F("email"), "%@EXAMPLE.COM"),
+						sb.Regexp(sb.F("phone"), "^1[0-9]{10}$"),
+						sb.NullSafeEq(sb.F("deleted_at"), nil),
+						sb.DistinctFrom(sb.F("role"), "admin"),
+						sb.NotBetween(sb.F("age"), 18, 60),
+						sb.IsTrue(sb.F("verified")),
+						sb.IsFalse(sb.F("locked")),
+					).Build()
+			},
+			wantSql: "SELECT * FROM `user` WHERE `name` NOT LIKE ? AND LOWER(`email`) LIKE LOWER(?) AND `phone` REGEXP ?" +
+				" AND `deleted_at` <=> ? AND NOT (`role` <=> ?) AND `age` NOT BETWEEN ? AND ? AND `verified` IS TRUE AND `locked` IS FALSE",
+			wantArgs: []any{"test%", "%@EXAMPLE.COM", "^1[0-9]{10}$", nil, "admin", 18, 60},
+		},
+		{
+			name: "row values",
+			workFn: func() (string, []any) {
+				return sb.New().Select().Field().
+					From("order").
+					Where(
+						sb.In(sb.Tuple("tenant_id", "id"), sb.Row(1, 10), sb.Row(1, 11)),
+						sb.Gt(sb.Tuple(sb.F("created_at"), sb.F("id")), sb.Row("2024-01-01", 100)),
+					).
+					OrderBy(sb.O(sb.F("created_at"), sb.Asc), sb.O(sb.F("id"), sb.Asc)).
+					Limit(20).Build()
+			},
+			wantSql: "SELECT * FROM `order` WHERE (`tenant_id`,`id`) IN ((?,?),(?,?)) AND (`created_at`,`id`) > (?,?)" +
+				" ORDER BY `created_at` ASC,`id` ASC LIMIT ?",
+			wantArgs: []any{1, 10, 1, 11, "2024-01-01", 100, 20},
+		},
+		{
+			name: "column to column comparisons",
+			workFn: func() (string, []any) {
+				return sb.New().Select().Field(sb.F("o", "id")).
+					FromT(sb.T("order", "o"), sb.T("promotion", "p")).
+					Where(
+						sb.Eq(sb.F("o", "promotion_id"), sb.F("p", "id")),
+						sb.Between(sb.F("o", "created_at"), sb.Col("p", "start_at"), sb.Col("p", "end_at")),
+						sb.Ge(sb.F("o", "amount"), 100),
+					).Build()
+			},
+			wantSql: "SELECT `o`.`id` FROM `order` AS `o`,`promotion` AS `p` WHERE `o`.`promotion_id` = `p`.`id`" +
+				" AND `o`.`created_at` BETWEEN `p`.`start_at` AND `p`.`end_at` AND `o`.`amount` >= ?",
+			wantArgs: []any{100},
+		},
+		{
+			name: "equality conditions from a map and a struct",
+			workFn: func() (string, []any) {
+				type filter struct {
+					Class string  `db:"class"`
+					Ages  []int   `db:"age"`
+					Name  *string `db:"name"`
+				}
+				return sb.New().Select().Field().
+					From("student").
+					Where(
+						sb.EqMap(map[string]any{"status": 1, "type": []int{1, 2}, "deleted_at": nil}),
+						sb.EqStruct(filter{Ages: []int{20, 21}}),
+					).Build()
+			},
+			wantSql:  "SELECT * FROM `student` WHERE (`deleted_at` IS NULL AND `status` = ? AND `type` IN (?,?)) AND `age` IN (?,?)",
+			wantArgs: []any{1, 1, 2, 20, 21},
+		},
+		{
+			name: "equality conditions from an empty slice",
+			workFn: func() (string, []any) {
+				return sb.New().Select().Field().
+					From("student").
+					Where(sb.EqMap(map[string]any{"id": []int64{}, "name": "alice"})).Build()
+			},
+			wantSql:  "SELECT * FROM `student` WHERE 1=0",
+			wantArgs: nil,
+		},
+		{
+			name: "full-text search",
+			workFn: func() (string, []any) {
+				m := sb.Match("title", "body").Against("+go -java", sb.BooleanMode)
+				return sb.New().Select().Field(sb.F("id"), m.As("score")).
+					From("article").
+					Where(m).
+					OrderBy(sb.O(m, sb.Desc)).Build()
+			},
+			wantSql: "SELECT `id`,MATCH (`title`,`body`) AGAINST (? IN BOOLEAN MODE) AS `score` FROM `article`" +
+				" WHERE MATCH (`title`,`body`) AGAINST (? IN BOOLEAN MODE) ORDER BY MATCH (`title`,`body`) AGAINST (? IN BOOLEAN MODE) DESC",
+			wantArgs: []any{"+go -java", "+go -java", "+go -java"},
+		},
+		{
+			name: "json paths and conditions",
+			workFn: func() (string, []any) {
+				return sb.New().Select().Field(sb.F("id"), sb.JSON(sb.F("attrs")).Path("$.sizes[0]").As("size")).
+					From("product").
+					Where(
+						sb.Eq(sb.JSON(sb.F("attrs")).Path("$.color").Text(), "red"),
+						sb.JSONContains(sb.F("tags"), []string{"sale"}),
+						sb.JSONOverlaps(sb.F("tags"), []string{"new", "hot"}),
+						sb.MemberOf("xl", sb.JSON(sb.F("attrs")).Path("$.sizes")),
+						sb.JSONHasKey(sb.F("attrs"), "weight"),
+					).Build()
+			},
+			wantSql: "SELECT `id`,`attrs`->'$.sizes[0]' AS `size` FROM `product` WHERE `attrs`->>'$.color' = ?" +
+				" AND JSON_CONTAINS(`tags`,?) AND JSON_OVERLAPS(`tags`,?) AND ? MEMBER OF(`attrs`->'$.sizes')" +
+				" AND JSON_CONTAINS_PATH(`attrs`,'one','$.\"weight\"')",
+			wantArgs: []any{"red", `["sale"]`, `["new","hot"]`, "xl"},
+		},
+		{
+			name: "group by aliased expressions",
+			workFn: func() (string, []any) {
+				day := sb.Fn("DATE", sb.F("created_at")).As("day")
+				color := sb.JSON(sb.F("attrs")).Path("$.color").As("color")
+				return sb.New().Select().Field(day, color, sb.Count().As("n")).
+					From("product").
+					GroupBy(day, color, sb.F("product", "shop_id", "shop")).Build()
+			},
+			wantSql: "SELECT DATE(`created_at`) AS `day`,`attrs`->'$.color' AS `color`,COUNT(*) AS `n` FROM `product`" +
+				" GROUP BY DATE(`created_at`),`attrs`->'$.color',`product`.`shop_id`",
+			wantArgs: nil,
+		},
+		{
+			name: "having without condition",
+			workFn: func() (string, []any) {
+				return sb.New().Select().Field(sb.F("shop_id")).From("deal").
+					GroupBy(sb.F("shop_id")).Having().OrderBy(sb.O(sb.F("shop_id"), sb.Asc)).Build()
+			},
+			wantSql:  "SELECT `shop_id` FROM `deal` GROUP BY `shop_id` ORDER BY `shop_id` ASC",
+			wantArgs: nil,
+		},
+		{
+			name: "column in the list of in",
+			workFn: func() (string, []any) {
+				return sb.New().Select().Field().From("user").
+					Where(sb.In(sb.F("a"), sb.F("b"), 1), sb.NotIn(sb.F("c"), 2, sb.F("u", "d"))).Build()
+			},
+			wantSql:  "SELECT * FROM `user` WHERE `a` IN (`b`,?) AND `c` NOT IN (?,`u`.`d`)",
+			wantArgs: []any{1, 2},
+		},
+		{
+			name: "case in field, where and order by",
+			workFn: func() (string, []any) {
+				return sb.New().Select().
+					Field(
+						sb.F("id"),
+						sb.Case("status").When(1, "active").When(2, "banned").Else("unknown").As("label"),
+					).
+					From("user").
+					Where(sb.Ne(sb.Case().When(sb.Gt(sb.F("score"), 90), sb.Raw("'A'")).Else(sb.F("grade")), "C")).
+					OrderBy(sb.O(sb.Case("level").When("vip", 0).Else(1), sb.Asc), sb.O(sb.F("id"), sb.Desc)).Build()
+			},
+			wantSql: "SELECT `id`,CASE `status` WHEN ? THEN ? WHEN ? THEN ? ELSE ? END AS `label` FROM `user`" +
+				" WHERE CASE WHEN `score` > ? THEN 'A' ELSE `grade` END != ?" +
+				" ORDER BY CASE `level` WHEN ? THEN ? ELSE ? END ASC,`id` DESC",
+			wantArgs: []any{1, "active", 2, "banned", "unknown", 90, "C", "vip", 0, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.workFn()
+			if sql != tt.wantSql {
+				t.Errorf("Select sql got = %v, want %v", sql, tt.wantSql)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("SELECT args got1 = %v, want %v", args, tt.wantArgs)
+			}
+			sqlCheck(t, sql)
+		})
+	}
+}
+
+func TestSqlBuilder_Delete(t *testing.T) {
+	tests := []struct {
+		name     string
+		workFn   func() (string, []any)
+		wantSql  string
+		wantArgs []any
+	}{
+		{
+			name: "DELETE",
+			workFn: func() (string, []any) {
+				return sb.New().Delete().From("demo").
+					Where(sb.Ge(sb.F("age"), 20)).
+					Order(sb.O(sb.F("name"), sb.Desc)).
+					Limit(10).Build()
+			},
+			wantSql:  "DELETE FROM `demo` WHERE `age` >= ? ORDER BY `name` DESC LIMIT ?",
+			wantArgs: []any{20, 10},
+		},
+		{
+			name: "DELETE",
+			workFn: func() (string, []any) {
+				return sb.New().Delete().From("demo").
+					Where(sb.Ge(sb.F("age"), 20)).
+					Limit(10).Build()
+			},
+			wantSql:  "DELETE FROM `demo` WHERE `age` >= ? LIMIT ?",
+			wantArgs: []any{20, 10},
+		},
+		{
+			name: "DELETE",
+			workFn: func() (string, []any) {
+				return sb.New().Delete().From("demo").
+					Where(sb.Ge(sb.F("age"), 20)).Build()
+			},
+			wantSql:  "DELETE FROM `demo` WHERE `age` >= ?",
+			wantArgs: []any{20},
+		},
+		{
+			name: "DELETE, group of a raw condition keeps its parentheses",
+			workFn: func() (string, []any) {
+				return sb.New().Delete().From("t").
+					Where(sb.Or(sb.Condition("a=1 OR b=2")), sb.Eq("c", 1)).Build()
+			},
+			wantSql:  "DELETE FROM `t` WHERE (a=1 OR b=2) AND `c` = ?",
+			wantArgs: []any{1},
+		},
+		{
+			name: "DELETE, empty nested group is dropped",
+			workFn: func() (string, []any) {
+				return sb.New().Delete().From("t").
+					Where(sb.Or(sb.Eq("id", 1), sb.And())).Build()
+			},
+			wantSql:  "DELETE FROM `t` WHERE `id` = ?",
+			wantArgs: []any{1},
+		},
+		{
+			name: "DELETE, without where",
+			workFn: func() (string, []any) {
+				return sb.New().Delete().From("demo").
+					Order(sb.O(sb.F("name"), sb.Desc)).
+					Limit(10).Build()
+			},
+			wantSql:  "DELETE FROM `demo` ORDER BY `name` DESC LIMIT ?",
+			wantArgs: []any{10},
+		},
+		{
+			name: "DELETE, without where",
+			workFn: func() (string, []any) {
+				return sb.New().Delete().From("demo").
+					Order(sb.O(sb.F("name"), sb.Desc)).
+					Build()
 			},
 			wantSql:  "DELETE FROM `demo` ORDER BY `name` DESC",
 			wantArgs: nil,
@@ -423,6 +1091,28 @@ func TestSqlBuilder_Delete(t *testing.T) {
 			wantSql:  "DELETE IGNORE FROM `demo`",
 			wantArgs: nil,
 		},
+		{
+			name: "DELETE, join",
+			workFn: func() (string, []any) {
+				return sb.New().Delete().Targets("o").
+					FromT(sb.T("orders", "o")).
+					InnerJoin(sb.T("users", "u")).On(sb.F("o", "user_id"), sb.F("u", "id")).
+					Where(sb.Eq(sb.F("u", "status"), 0)).Build()
+			},
+			wantSql:  "DELETE `o` FROM `orders` AS `o` INNER JOIN `users` AS `u` ON `o`.`user_id`=`u`.`id` WHERE `u`.`status` = ?",
+			wantArgs: []any{0},
+		},
+		{
+			name: "DELETE, multiple targets",
+			workFn: func() (string, []any) {
+				return sb.New().Delete().Targets("orders", "items").
+					From("orders").
+					LeftJoin(sb.T("items")).Using("order_id").
+					Build()
+			},
+			wantSql:  "DELETE `orders`,`items` FROM `orders` LEFT JOIN `items` USING (`order_id`)",
+			wantArgs: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -458,6 +1148,44 @@ func TestSqlBuilder_Update(t *testing.T) {
 			wantSql:  "UPDATE `demo` SET `name`=?,`age`=? WHERE `name` = ? LIMIT ?",
 			wantArgs: []any{"alice", 22, "bob", 5},
 		},
+		{
+			name: "Update with case",
+			workFn: func() (string, []any) {
+				return sb.New().Update().Table("account").
+					Set(sb.Set("tier", sb.Case().
+						When(sb.Ge(sb.F("balance"), 1000), "gold").
+						When(sb.Ge(sb.F("balance"), 100), "silver").
+						Else(sb.F("tier")))).
+					Where(sb.Eq(sb.F("active"), true)).Build()
+			},
+			wantSql:  "UPDATE `account` SET `tier`=CASE WHEN `balance` >= ? THEN ? WHEN `balance` >= ? THEN ? ELSE `tier` END WHERE `active` = ?",
+			wantArgs: []any{1000, "gold", 100, "silver", true},
+		},
+		{
+			name: "Update with set helpers",
+			workFn: func() (string, []any) {
+				return sb.New().Update().TableT(sb.T("post", "p")).
+					Set(
+						sb.Incr(sb.F("p", "views"), 1),
+						sb.Decr("stock", 2),
+						sb.SetExpr("updated_at", "NOW()"),
+						sb.SetColumn("title", sb.F("p", "draft_title")),
+						sb.SetNull("draft_title"),
+					).Where(sb.Eq(sb.F("p", "id"), 7)).Build()
+			},
+			wantSql:  "UPDATE `post` AS `p` SET `p`.`views`=`p`.`views`+?,`stock`=`stock`-?,`updated_at`=NOW(),`title`=`p`.`draft_title`,`draft_title`=NULL WHERE `p`.`id` = ?",
+			wantArgs: []any{1, 2, 7},
+		},
+		{
+			name: "Update json path",
+			workFn: func() (string, []any) {
+				return sb.New().Update().Table("product").
+					Set(sb.Set(sb.JSON(sb.F("attrs")).Path("$.color"), "red")).
+					Where(sb.Eq(sb.F("id"), 7)).Build()
+			},
+			wantSql:  "UPDATE `product` SET `attrs`=JSON_SET(`attrs`,'$.color',?) WHERE `id` = ?",
+			wantArgs: []any{"red", 7},
+		},
 		{
 			name: "Update without where",
 			workFn: func() (string, []any) {
@@ -522,6 +1250,62 @@ func TestSqlBuilder_Update(t *testing.T) {
 			wantSql:  "UPDATE IGNORE `demo` SET `name`=?,`age`=? WHERE `name` = ?",
 			wantArgs: []any{"alice", 22, "bob"},
 		},
+		{
+			name: "Update from map",
+			workFn: func() (string, []any) {
+				return sb.New().Update().Table("demo").
+					SetMap(map[string]any{
+						"name": "alice",
+						"age":  22,
+					}).Where(sb.Eq(sb.F("id"), 1)).Build()
+			},
+			wantSql:  "UPDATE `demo` SET `age`=?,`name`=? WHERE `id` = ?",
+			wantArgs: []any{22, "alice", 1},
+		},
+		{
+			name: "Update from struct",
+			workFn: func() (string, []any) {
+				return sb.New().Update().Table("demo").
+					SetStruct(&student{ID: 1, Name: "alice", Age: 22}, sb.Exclude("id")).
+					Where(sb.Eq(sb.F("id"), 1)).Build()
+			},
+			wantSql:  "UPDATE `demo` SET `name`=?,`age`=?,`class`=? WHERE `id` = ?",
+			wantArgs: []any{"alice", 22, "", 1},
+		},
+		{
+			name: "Update from struct, omit zero",
+			workFn: func() (string, []any) {
+				return sb.New().Update().Table("demo").
+					SetStruct(student{ID: 1, Age: 22}, sb.OmitZero(), sb.Include("name", "age", "class")).
+					Where(sb.Eq(sb.F("id"), 1)).Build()
+			},
+			wantSql:  "UPDATE `demo` SET `age`=? WHERE `id` = ?",
+			wantArgs: []any{22, 1},
+		},
+		{
+			name: "Update join",
+			workFn: func() (string, []any) {
+				return sb.New().Update().TableT(sb.T("orders", "o")).
+					InnerJoin(sb.T("users", "u")).On(sb.F("o", "user_id"), sb.F("u", "id")).
+					Set(
+						sb.Set(sb.F("o", "tier"), sb.F("u", "tier")),
+						sb.Set(sb.F("o", "synced"), 1),
+					).Where(sb.Gt(sb.F("u", "level"), 3)).Build()
+			},
+			wantSql:  "UPDATE `orders` AS `o` INNER JOIN `users` AS `u` ON `o`.`user_id`=`u`.`id` SET `o`.`tier`=`u`.`tier`,`o`.`synced`=? WHERE `u`.`level` > ?",
+			wantArgs: []any{1, 3},
+		},
+		{
+			name: "Update multiple joins",
+			workFn: func() (string, []any) {
+				return sb.New().Update().Table("orders").
+					LeftJoin(sb.T("users")).Using("user_id").
+					CrossJoin(sb.T("config")).
+					Set(sb.Set(sb.F("orders", "tier"), sb.F("config", "tier"))).Build()
+			},
+			wantSql:  "UPDATE `orders` LEFT JOIN `users` USING (`user_id`) CROSS JOIN `config` SET `orders`.`tier`=`config`.`tier`",
+			wantArgs: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -536,3 +1320,1266 @@ func TestSqlBuilder_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestSqlBuilder_Dialect(t *testing.T) {
+	tests := []struct {
+		name     string
+		workFn   func() (string, []any, error)
+		wantSql  string
+		wantArgs []any
+		wantErr  bool
+		// wantErrIs is the error expected when wantErr is set, ErrUnsupported if nil.
+		wantErrIs error
+	}{
+		{
+			name: "postgresql, quote identifiers",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Select().Field(sb.F("d", "name")).
+					FromT(sb.T("demo", "d")).
+					Where(sb.Eq(sb.F("d", "id"), 1)).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `SELECT "d"."name" FROM "demo" AS "d" WHERE "d"."id" = ?`,
+			wantArgs: []any{1},
+		},
+		{
+			name: "postgresql, full join",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Select().Field().
+					FromT(sb.T("a")).
+					FullJoin(sb.T("b")).Using("id").Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `SELECT * FROM "a" FULL OUTER JOIN "b" USING ("id")`,
+			wantArgs: nil,
+		},
+		{
+			name: "mysql, full join is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Select().Field().
+					FromT(sb.T("a")).
+					FullJoin(sb.T("b")).Using("id").Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "postgresql, straight join is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Select().Field().
+					FromT(sb.T("a")).
+					StraightJoin(sb.T("b")).Using("id").Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "postgresql, lateral join",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sub := sb.Sub(sb.New().Dialect(sb.PostgreSQL).Select().Field("total").
+					From("orders").
+					Where(sb.Condition(`"orders"."user_id"="u"."id"`), sb.Gt(sb.F("total"), 10)).
+					Limit(3).Build()).As("o")
+				sql, args := b.Select().Field().
+					FromT(sb.T("users", "u")).
+					LeftJoinLateral(sub).OnCondition(sb.Condition("TRUE")).
+					Where(sb.Eq(sb.F("u", "id"), 1)).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `SELECT * FROM "users" AS "u" LEFT JOIN LATERAL (SELECT "total" FROM "orders" WHERE "orders"."user_id"="u"."id" AND "total" > ? LIMIT ?) AS "o" ON TRUE WHERE "u"."id" = ?`,
+			wantArgs: []any{10, 3, 1},
+		},
+		{
+			name: "sql server, cross apply",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLServer)
+				sql, args := b.Select().Field().
+					FromT(sb.T("users", "u")).
+					CrossJoinLateral(sb.Sub("SELECT 1 AS x", nil).As("o")).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `SELECT * FROM "users" AS "u" CROSS APPLY (SELECT 1 AS x) AS "o"`,
+			wantArgs: nil,
+		},
+		{
+			name: "postgresql, update from",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Update().TableT(sb.T("orders", "o")).
+					InnerJoin(sb.T("users", "u")).
+					OnCondition(sb.Condition(`"o"."user_id"="u"."id"`), sb.Eq(sb.F("u", "status"), 1)).
+					LeftJoin(sb.T("levels", "l")).On(sb.F("l", "id"), sb.F("u", "level_id")).
+					Set(
+						sb.Set(sb.F("o", "tier"), sb.F("l", "tier")),
+						sb.Set(sb.F("o", "synced"), true),
+					).Where(sb.Gt(sb.F("o", "id"), 100)).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `UPDATE "orders" AS "o" SET "tier"="l"."tier","synced"=? FROM "users" AS "u" LEFT JOIN "levels" AS "l" ON "l"."id"="u"."level_id" WHERE "o"."user_id"="u"."id" AND "u"."status" = ? AND "o"."id" > ?`,
+			wantArgs: []any{true, 1, 100},
+		},
+		{
+			name: "sqlite, update from using",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLite)
+				sql, args := b.Update().Table("orders").
+					InnerJoin(sb.T("users", "u")).Using("user_id", "tenant_id").
+					Set(sb.Set(sb.F("orders", "tier"), sb.F("u", "tier"))).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `UPDATE "orders" SET "tier"="u"."tier" FROM "users" AS "u" WHERE "orders"."user_id"="u"."user_id" AND "orders"."tenant_id"="u"."tenant_id"`,
+			wantArgs: nil,
+		},
+		{
+			name: "postgresql, update from must start with an inner join",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Update().Table("orders").
+					LeftJoin(sb.T("users")).Using("user_id").
+					Set(sb.Set(sb.F("tier"), 1)).Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "oracle, update join is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.Oracle)
+				sql, args := b.Update().Table("orders").
+					InnerJoin(sb.T("users")).Using("user_id").
+					Set(sb.Set(sb.F("tier"), 1)).Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "postgresql, delete using",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Delete().Targets("o").
+					FromT(sb.T("orders", "o")).
+					InnerJoin(sb.T("users", "u")).On(sb.F("o", "user_id"), sb.F("u", "id")).
+					Where(sb.Eq(sb.F("u", "status"), 0)).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `DELETE FROM "orders" AS "o" USING "users" AS "u" WHERE "o"."user_id"="u"."id" AND "u"."status" = ?`,
+			wantArgs: []any{0},
+		},
+		{
+			name: "postgresql, delete using without where",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Delete().Targets("orders").
+					From("orders").
+					InnerJoin(sb.T("users")).Using("user_id").
+					Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `DELETE FROM "orders" USING "users" WHERE "orders"."user_id"="users"."user_id"`,
+			wantArgs: nil,
+		},
+		{
+			name: "postgresql, delete from a joined table is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Delete().Targets("u").
+					FromT(sb.T("orders", "o")).
+					InnerJoin(sb.T("users", "u")).Using("user_id").
+					Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "sqlite, delete join is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLite)
+				sql, args := b.Delete().Targets("orders").
+					From("orders").
+					InnerJoin(sb.T("users")).Using("user_id").
+					Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "postgresql, insert ... set",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Insert().Into("demo").
+					Set(sb.Set("name", "alice"), sb.Set("age", 20)).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `INSERT INTO "demo" ("name","age") VALUES (?,?)`,
+			wantArgs: []any{"alice", 20},
+		},
+		{
+			name: "postgresql, insert ... set with expression is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Insert().Into("demo").
+					Set(sb.Set("name", "alice"), sb.Value(`"age"=1`)).Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "mysql, insert row alias",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Insert().Into("demo").
+					Fields("id", "name").
+					Values(1, "alice").
+					RowAlias("new").
+					OnDuplicateUpdateAll("id").Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  "INSERT INTO `demo` (`id`,`name`) VALUES (?,?) AS `new` ON DUPLICATE KEY UPDATE `name`=`new`.`name`",
+			wantArgs: []any{1, "alice"},
+		},
+		{
+			name: "mariadb, insert row alias is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.MariaDB)
+				sql, args := b.Insert().Into("demo").
+					Fields("id", "name").
+					Values(1, "alice").
+					RowAlias("new").
+					OnDuplicateUpdateAll("id").Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "sqlite, insert or replace",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLite)
+				sql, args := b.Replace().Into("config").
+					Fields("key", "value").
+					Values("theme", "dark").Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `INSERT OR REPLACE INTO "config" ("key","value") VALUES (?,?)`,
+			wantArgs: []any{"theme", "dark"},
+		},
+		{
+			name: "postgresql, replace is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Replace().Into("config").
+					Fields("key", "value").
+					Values("theme", "dark").Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "postgresql, on conflict do update",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Insert().Into("demo").
+					Fields("id", "name", "age").
+					Values(1, "alice", 20).
+					OnConflict("id").
+					DoUpdate(
+						sb.UpdateFromInsert("name"),
+						sb.Set(sb.F("demo", "age"), sb.Excluded("age")),
+						sb.Set("version", 2),
+					).
+					Where(sb.Lt(sb.F("demo", "version"), 2)).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `INSERT INTO "demo" ("id","name","age") VALUES (?,?,?) ON CONFLICT ("id") DO UPDATE SET "name"=EXCLUDED."name","age"=EXCLUDED."age","version"=? WHERE "demo"."version" < ?`,
+			wantArgs: []any{1, "alice", 20, 2, 2},
+		},
+		{
+			name: "postgresql, on conflict do update reads columns of the table",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Insert().Into("counter").
+					Fields("id", "n").
+					Values(1, 1).
+					OnConflict("id").
+					DoUpdate(sb.Incr("n", 1), sb.SetColumn("last", "n")).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `INSERT INTO "counter" ("id","n") VALUES (?,?) ON CONFLICT ("id") DO UPDATE SET "n"="counter"."n"+?,"last"="counter"."n"`,
+			wantArgs: []any{1, 1, 1},
+		},
+		{
+			name: "sqlite, on conflict do update reads columns unqualified",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLite)
+				sql, args := b.Insert().Into("counter").
+					Fields("id", "n").
+					Values(1, 1).
+					OnConflict("id").
+					DoUpdate(sb.Incr("n", 1)).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `INSERT INTO "counter" ("id","n") VALUES (?,?) ON CONFLICT ("id") DO UPDATE SET "n"="n"+?`,
+			wantArgs: []any{1, 1, 1},
+		},
+		{
+			name: "postgresql, on conflict on constraint do nothing",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Insert().Into("demo").
+					Fields("id", "name").
+					Select(`SELECT "id","name" FROM "demo2"`).
+					OnConflictConstraint("demo_pkey").
+					DoNothing().Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `INSERT INTO "demo" ("id","name") SELECT "id","name" FROM "demo2" ON CONFLICT ON CONSTRAINT "demo_pkey" DO NOTHING`,
+			wantArgs: nil,
+		},
+		{
+			name: "sqlite, on conflict of partial index",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLite)
+				sql, args := b.Insert().Into("demo").
+					Fields("name", "age").
+					Values("alice", 20).
+					OnConflict("name").Where(sb.IsNull(sb.F("deleted_at"))).
+					DoNothing().Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `INSERT INTO "demo" ("name","age") VALUES (?,?) ON CONFLICT ("name") WHERE "deleted_at" IS NULL DO NOTHING`,
+			wantArgs: []any{"alice", 20},
+		},
+		{
+			name: "mysql, on conflict is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Insert().Into("demo").
+					Fields("name").
+					Values("alice").
+					OnConflict().DoNothing().Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "postgresql, insert returning",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Insert().Into("demo").
+					Fields("name").
+					Values("alice").
+					Returning("id", sb.F("created_at")).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `INSERT INTO "demo" ("name") VALUES (?) RETURNING "id","created_at"`,
+			wantArgs: []any{"alice"},
+		},
+		{
+			name: "sqlite, update returning",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLite)
+				sql, args := b.Update().Table("demo").
+					Set(sb.Set("name", "bob")).
+					Where(sb.Eq(sb.F("id"), 1)).
+					Returning().Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `UPDATE "demo" SET "name"=? WHERE "id" = ? RETURNING *`,
+			wantArgs: []any{"bob", 1},
+		},
+		{
+			name: "mariadb, delete returning",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.MariaDB)
+				sql, args := b.Delete().From("demo").
+					Where(sb.Eq(sb.F("id"), 1)).
+					Returning("id").Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  "DELETE FROM `demo` WHERE `id` = ? RETURNING `id`",
+			wantArgs: []any{1},
+		},
+		{
+			name: "mariadb, update returning is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.MariaDB)
+				sql, args := b.Update().Table("demo").
+					Set(sb.Set("name", "bob")).
+					Returning().Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "mysql, returning is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Insert().Into("demo").
+					Fields("name").
+					Values("alice").
+					Returning("id").Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql server, insert output",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLServer)
+				sql, args := b.Insert().Into("demo").
+					Fields("name", "age").
+					Bulk(2, func(index int) []any {
+						return []any{"alice", 20 + index}
+					}).
+					Returning("id", sb.F("demo", "name")).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `INSERT INTO "demo" ("name","age") OUTPUT INSERTED."id",INSERTED."name" VALUES (?,?),(?,?)`,
+			wantArgs: []any{"alice", 20, "alice", 21},
+		},
+		{
+			name: "sql server, update output",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLServer)
+				sql, args := b.Update().Table("demo").
+					Set(sb.Set("name", "bob")).
+					Where(sb.Eq(sb.F("id"), 1)).
+					Returning().Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `UPDATE "demo" SET "name"=? OUTPUT INSERTED.* WHERE "id" = ?`,
+			wantArgs: []any{"bob", 1},
+		},
+		{
+			name: "sql server, delete output",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLServer)
+				sql, args := b.Delete().From("demo").
+					Where(sb.Eq(sb.F("id"), 1)).
+					Returning("id").Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `DELETE FROM "demo" OUTPUT DELETED."id" WHERE "id" = ?`,
+			wantArgs: []any{1},
+		},
+		{
+			name: "sql server, output of aliases and expressions",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLServer)
+				sql, args := b.Update().Table("demo").
+					Set(sb.Set("name", "bob")).
+					Where(sb.Eq(sb.F("id"), 1)).
+					Returning(sb.F("demo", "id", "demo_id"), sb.Raw("CONCAT(INSERTED.[name], ?)", "!").As("shout")).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `UPDATE "demo" SET "name"=? OUTPUT INSERTED."id" AS "demo_id",CONCAT(INSERTED.[name], ?) AS "shout" WHERE "id" = ?`,
+			wantArgs: []any{"bob", "!", 1},
+		},
+		{
+			name: "postgresql, upsert",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Upsert().Into("config").
+					Fields("key", "value").
+					Values("theme", "dark").
+					ConflictOn("key").
+					Update().Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `INSERT INTO "config" ("key","value") VALUES (?,?) ON CONFLICT ("key") DO UPDATE SET "value"=EXCLUDED."value"`,
+			wantArgs: []any{"theme", "dark"},
+		},
+		{
+			name: "sql server, upsert",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLServer)
+				sql, args := b.Upsert().Into("config").
+					Fields("key", "value").
+					Bulk(2, func(index int) []any {
+						return []any{index, "dark"}
+					}).
+					ConflictOn("key").
+					Update().Build()
+				return sql, args, b.Err()
+			},
+			wantSql: `MERGE INTO "config" USING (VALUES (?,?),(?,?)) AS "src" ("key","value") ON ("config"."key"="src"."key")` +
+				` WHEN MATCHED THEN UPDATE SET "value"="src"."value"` +
+				` WHEN NOT MATCHED THEN INSERT ("key","value") VALUES ("src"."key","src"."value");`,
+			wantArgs: []any{0, "dark", 1, "dark"},
+		},
+		{
+			name: "oracle, upsert",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.Oracle)
+				sql, args := b.Upsert().IntoT(sb.T("config", "c")).
+					Fields("key", "value").
+					Values("theme", "dark").
+					ConflictOn("key").
+					Update("value").Build()
+				return sql, args, b.Err()
+			},
+			wantSql: `MERGE INTO "config" "c" USING (SELECT ? "key",? "value" FROM dual) "src" ON ("c"."key"="src"."key")` +
+				` WHEN MATCHED THEN UPDATE SET "value"="src"."value"` +
+				` WHEN NOT MATCHED THEN INSERT ("key","value") VALUES ("src"."key","src"."value")`,
+			wantArgs: []any{"theme", "dark"},
+		},
+		{
+			name: "sql server, upsert expression values",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLServer)
+				sql, args := b.Upsert().Into("config").
+					Fields("key", "value").
+					Values(sb.Raw("LOWER(?)", "Theme"), "dark").
+					ConflictOn("key").
+					Update().Build()
+				return sql, args, b.Err()
+			},
+			wantSql: `MERGE INTO "config" USING (VALUES (LOWER(?),?)) AS "src" ("key","value") ON ("config"."key"="src"."key")` +
+				` WHEN MATCHED THEN UPDATE SET "value"="src"."value"` +
+				` WHEN NOT MATCHED THEN INSERT ("key","value") VALUES ("src"."key","src"."value");`,
+			wantArgs: []any{"Theme", "dark"},
+		},
+		{
+			name: "oracle, upsert expression values",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.Oracle)
+				sql, args := b.Upsert().Into("config").
+					Fields("key", "value").
+					Values("theme", sb.Raw("LOWER(?)", "Dark")).
+					ConflictOn("key").
+					Update().Build()
+				return sql, args, b.Err()
+			},
+			wantSql: `MERGE INTO "config" USING (SELECT ? "key",LOWER(?) "value" FROM dual) "src" ON ("config"."key"="src"."key")` +
+				` WHEN MATCHED THEN UPDATE SET "value"="src"."value"` +
+				` WHEN NOT MATCHED THEN INSERT ("key","value") VALUES ("src"."key","src"."value")`,
+			wantArgs: []any{"theme", "Dark"},
+		},
+		{
+			name: "sql server, default in merge is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLServer)
+				sql, args := b.Upsert().Into("config").
+					Fields("key", "value").
+					Values("theme", sb.Default).
+					ConflictOn("key").
+					Update().Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "sqlite, upsert without conflict key",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLite)
+				sql, args := b.Upsert().Into("config").
+					Fields("key", "value").
+					Values("theme", "dark").
+					ConflictOn().
+					Update("value").Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrNoConflictKey,
+		},
+		{
+			name: "insert ... select, column count mismatch",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Insert().Into("demo").
+					Fields("name", "age").
+					Select(sb.New().Select().Field("name").From("user")).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrColumnCount,
+		},
+		{
+			name: "insert ... select, dialect mismatch",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Insert().Into("demo").
+					Fields("name").
+					Select(sb.New().Select().Field("name").From("user")).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrDialectMismatch,
+		},
+		{
+			name: "postgresql, insert ... select all",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Insert().Into("demo").
+					Fields("name", "age").
+					Select(sb.New().Dialect(sb.PostgreSQL).Select().Field().From("user").Limit(10)).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `INSERT INTO "demo" ("name","age") SELECT * FROM "user" LIMIT ?`,
+			wantArgs: []any{10},
+		},
+		{
+			name: "postgresql, insert default values",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Insert().Into("demo").DefaultValues().Returning("id").Build()
+				return sql, args, b.Err()
+			},
+			wantSql: `INSERT INTO "demo" DEFAULT VALUES RETURNING "id"`,
+		},
+		{
+			name: "sql server, insert default values",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLServer)
+				sql, args := b.Insert().Into("demo").DefaultValues().Returning("id").Build()
+				return sql, args, b.Err()
+			},
+			wantSql: `INSERT INTO "demo" OUTPUT INSERTED."id" DEFAULT VALUES`,
+		},
+		{
+			name: "sqlite, default value is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLite)
+				sql, args := b.Insert().Into("demo").
+					Fields("id", "name").
+					Values(sb.Default, "alice").Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "postgresql, update with set helpers",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Update().TableT(sb.T("post", "p")).
+					Set(sb.Incr(sb.F("p", "views"), 1), sb.SetNull(sb.F("p", "draft"))).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `UPDATE "post" AS "p" SET "views"="p"."views"+?,"draft"=NULL`,
+			wantArgs: []any{1},
+		},
+		{
+			name: "postgresql, aggregates",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Select().
+					Field(
+						sb.Count().Filter(sb.Eq(sb.F("status"), 1)).As("paid"),
+						sb.StringAgg(sb.F("name"), ", ").OrderBy(sb.O(sb.F("name"), sb.Asc)),
+						sb.JSONArrayAgg(sb.F("id")).OrderBy(sb.O(sb.F("id"), sb.Desc)),
+					).
+					From("deal").Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `SELECT COUNT(*) FILTER (WHERE "status" = ?) AS "paid",STRING_AGG("name",', ' ORDER BY "name" ASC),JSON_AGG("id" ORDER BY "id" DESC) FROM "deal"`,
+			wantArgs: []any{1},
+		},
+		{
+			name: "sql server, string_agg",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLServer)
+				sql, args := b.Select().
+					Field(sb.GroupConcat(sb.F("name")).OrderBy(sb.O(sb.F("name"), sb.Asc)).As("names")).
+					From("deal").Build()
+				return sql, args, b.Err()
+			},
+			wantSql: `SELECT STRING_AGG("name",',') WITHIN GROUP (ORDER BY "name" ASC) AS "names" FROM "deal"`,
+		},
+		{
+			name: "oracle, listagg",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.Oracle)
+				sql, args := b.Select().
+					Field(sb.StringAgg(sb.F("name"), "'")).
+					From("deal").Build()
+				return sql, args, b.Err()
+			},
+			wantSql: `SELECT LISTAGG("name",'''') WITHIN GROUP (ORDER BY NULL) FROM "deal"`,
+		},
+		{
+			name: "mysql, ordered json_arrayagg is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Select().
+					Field(sb.JSONArrayAgg(sb.F("id")).OrderBy(sb.O(sb.F("id"), sb.Asc))).
+					From("deal").Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "mysql, filter of a parameterized aggregate is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Select().
+					Field(sb.Sum(sb.Raw("price * ?", 2)).Filter(sb.Eq(sb.F("status"), 1))).
+					From("deal").Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql server, regexp is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLServer)
+				sql, args := b.Select().Field().From("user").
+					Where(sb.Regexp(sb.F("name"), "^a")).Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "sql server, row values are expanded",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLServer)
+				sql, args := b.Select().Field().From("order").
+					Where(
+						sb.In(sb.Tuple("tenant_id", "id"), sb.Row(1, 10), sb.Row(1, 11)),
+						sb.NotIn(sb.Tuple("tenant_id", "id"), sb.Row(2, 20)),
+						sb.Ge(sb.Tuple("created_at", "id"), sb.Row("2024-01-01", 100)),
+					).Build()
+				return sql, args, b.Err()
+			},
+			wantSql: `SELECT * FROM "order" WHERE (("tenant_id" = ? AND "id" = ?) OR ("tenant_id" = ? AND "id" = ?))` +
+				` AND NOT ("tenant_id" = ? AND "id" = ?)` +
+				` AND ("created_at" > ? OR ("created_at" = ? AND "id" >= ?))`,
+			wantArgs: []any{1, 10, 1, 11, 2, 20, "2024-01-01", "2024-01-01", 100},
+		},
+		{
+			name: "oracle, row value in is native",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.Oracle)
+				sql, args := b.Select().Field().From("order").
+					Where(
+						sb.In(sb.Tuple("tenant_id", "id"), sb.Row(1, 10)),
+						sb.Lt(sb.Tuple("a", "b", "c"), sb.Row(1, 2, 3)),
+					).Build()
+				return sql, args, b.Err()
+			},
+			wantSql: `SELECT * FROM "order" WHERE ("tenant_id","id") IN ((?,?))` +
+				` AND ("a" < ? OR ("a" = ? AND "b" < ?) OR ("a" = ? AND "b" = ? AND "c" < ?))`,
+			wantArgs: []any{1, 10, 1, 1, 2, 1, 2, 3},
+		},
+		{
+			name: "row value size mismatch",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLServer)
+				sql, args := b.Select().Field().From("order").
+					Where(sb.Eq(sb.Tuple("tenant_id", "id"), sb.Row(1))).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrColumnCount,
+		},
+		{
+			name: "row value compared to a plain value",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Select().Field().From("order").
+					Where(sb.Eq(sb.Tuple("tenant_id", "id"), 1)).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrColumnCount,
+		},
+		{
+			name: "row value size mismatch with row value support",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Select().Field().From("order").
+					Where(sb.In(sb.Tuple("tenant_id", "id"), sb.Row(1, 10), sb.Row(2))).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrColumnCount,
+		},
+		{
+			name: "postgresql, full-text search",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				m := sb.Match("title", "body").Against("go -java", sb.BooleanMode)
+				sql, args := b.Select().Field(sb.F("id"), m.As("score")).From("article").
+					Where(m, sb.Match("tag").Against("go", "")).
+					OrderBy(sb.O(m, sb.Desc)).Build()
+				return sql, args, b.Err()
+			},
+			wantSql: `SELECT "id",ts_rank(to_tsvector(concat_ws(' ',"title","body")),websearch_to_tsquery(?)) AS "score" FROM "article"` +
+				` WHERE to_tsvector(concat_ws(' ',"title","body")) @@ websearch_to_tsquery(?) AND to_tsvector("tag") @@ plainto_tsquery(?)` +
+				` ORDER BY ts_rank(to_tsvector(concat_ws(' ',"title","body")),websearch_to_tsquery(?)) DESC`,
+			wantArgs: []any{"go -java", "go -java", "go", "go -java"},
+		},
+		{
+			name: "sqlserver, full-text search",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLServer)
+				sql, args := b.Select().Field().From("article").
+					Where(
+						sb.Match("title", "body").Against("go", sb.BooleanMode),
+						sb.Match("title").Against("golang", sb.NaturalLanguageMode),
+					).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `SELECT * FROM "article" WHERE CONTAINS(("title","body"),?) AND FREETEXT("title",?)`,
+			wantArgs: []any{"go", "golang"},
+		},
+		{
+			name: "oracle, full-text search",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.Oracle)
+				sql, args := b.Select().Field().From("article").
+					Where(sb.Match("body").Against("go", "")).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `SELECT * FROM "article" WHERE CONTAINS("body",?) > 0`,
+			wantArgs: []any{"go"},
+		},
+		{
+			name: "sqlite, full-text search relevance is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLite)
+				m := sb.Match("body").Against("go", "")
+				sql, args := b.Select().Field().From("article").Where(m).OrderBy(sb.O(m, sb.Desc)).Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "postgresql, full-text search with query expansion is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Select().Field().From("article").
+					Where(sb.Match("body").Against("go", sb.QueryExpansion)).Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "postgresql, json paths and conditions",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Select().Field(sb.JSON(sb.F("attrs")).Path(`$.sizes[0]`).As("size")).
+					From("product").
+					Where(
+						sb.Eq(sb.JSON(sb.F("attrs")).Path(`$.dims."max width"`).Text(), "10"),
+						sb.JSONContains(sb.F("tags"), []string{"sale"}),
+						sb.JSONOverlaps(sb.F("tags"), []string{"new", "hot"}),
+						sb.MemberOf("xl", sb.JSON(sb.F("attrs")).Path("$.sizes")),
+						sb.JSONHasKey(sb.F("attrs"), "weight"),
+					).Build()
+				return sql, args, b.Err()
+			},
+			wantSql: `SELECT "attrs"->'sizes'->0 AS "size" FROM "product" WHERE "attrs"->'dims'->>'max width' = ?` +
+				` AND "tags" @> ? AND EXISTS (SELECT 1 FROM jsonb_array_elements(?) AS "e"("v") WHERE "tags" @> jsonb_build_array("v"))` +
+				` AND "attrs"->'sizes' @> ? AND jsonb_exists("attrs",'weight')`,
+			wantArgs: []any{"10", `["sale"]`, `["new","hot"]`, `["xl"]`},
+		},
+		{
+			name: "postgresql, update json path",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Update().Table("product").
+					Set(sb.Set(sb.JSON(sb.F("attrs")).Path("$.sizes[1]"), "xl")).
+					Where(sb.Eq(sb.F("id"), 7)).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `UPDATE "product" SET "attrs"=jsonb_set("attrs",'{"sizes",1}',?) WHERE "id" = ?`,
+			wantArgs: []any{`"xl"`, 7},
+		},
+		{
+			name: "mariadb, json paths and member of",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.MariaDB)
+				sql, args := b.Select().Field().From("product").
+					Where(
+						sb.Eq(sb.JSON(sb.F("attrs")).Path("$.color").Text(), "red"),
+						sb.MemberOf("xl", sb.JSON(sb.F("attrs")).Path("$.sizes")),
+					).Build()
+				return sql, args, b.Err()
+			},
+			wantSql: "SELECT * FROM `product` WHERE JSON_UNQUOTE(JSON_EXTRACT(`attrs`,'$.color')) = ?" +
+				" AND JSON_CONTAINS(JSON_EXTRACT(`attrs`,'$.sizes'),?)",
+			wantArgs: []any{"red", `"xl"`},
+		},
+		{
+			name: "sqlserver, json paths",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLServer)
+				sql, args := b.Select().Field(sb.JSON(sb.F("attrs")).Path("$.dims").As("dims")).From("product").
+					Where(sb.Eq(sb.JSON(sb.F("attrs")).Path("$.color").Text(), "red")).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `SELECT JSON_QUERY("attrs",'$.dims') AS "dims" FROM "product" WHERE JSON_VALUE("attrs",'$.color') = ?`,
+			wantArgs: []any{"red"},
+		},
+		{
+			name: "sqlite, json conditions are unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLite)
+				sql, args := b.Select().Field().From("product").
+					Where(sb.JSONContains(sb.F("tags"), []string{"sale"})).Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "postgresql, json path with a wildcard",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Select().Field(sb.JSON(sb.F("attrs")).Path("$.sizes[*]")).From("product").Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrJSONPath,
+		},
+		{
+			name: "upsert row of the wrong width",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Upsert().Into("config").
+					Fields("key", "value").
+					Values("theme").
+					ConflictOn("key").
+					Update().Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrColumnCount,
+		},
+		{
+			name: "upsert bulk row of the wrong width",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Upsert().Into("config").
+					Fields("key", "value").
+					Bulk(2, func(index int) []any {
+						if index == 1 {
+							return []any{"lang"}
+						}
+						return []any{"theme", "dark"}
+					}).
+					ConflictOn("key").
+					Update().Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrColumnCount,
+		},
+		{
+			name: "upsert bulk without row",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Upsert().Into("config").
+					Fields("key", "value").
+					Bulk(0, func(index int) []any { return nil }).
+					ConflictOn("key").
+					Update().Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrNoRows,
+		},
+		{
+			name: "insert pairs into an expression",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Insert().Into("user").
+					Pairs(sb.Set("name", "alice"), sb.Set(sb.Raw("UPPER(`name`)"), "BOB")).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrInvalidColumn,
+		},
+		{
+			name: "insert pairs with several values for a column",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Insert().Into("user").
+					Pairs(sb.Set("name", "alice", "bob"), sb.Set("age", 20)).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrColumnCount,
+		},
+		{
+			name: "insert set with several values for a column",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Insert().Into("user").
+					Set(sb.Set("name", "alice", "bob")).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrColumnCount,
+		},
+		{
+			name: "postgresql, on duplicate key update is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Insert().Into("counter").
+					Fields("id", "hits").
+					Values(1, 1).
+					OnDuplicate(sb.UpdateFromInsert("hits")).Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+		{
+			name: "postgresql, do update without conflict target",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Insert().Into("counter").
+					Fields("id", "hits").
+					Values(1, 1).
+					OnConflict().
+					DoUpdate(sb.Set("hits", sb.Excluded("hits"))).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrNoConflictKey,
+		},
+		{
+			name: "postgresql, do update without assignment",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Insert().Into("counter").
+					Fields("id", "hits").
+					Values(1, 1).
+					OnConflict("id").
+					DoUpdate().Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrNoColumnToUpdate,
+		},
+		{
+			name: "insert ... select chain with args",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Insert().Into("demo").
+					Fields("name").
+					Select(sb.New().Select().Field("name").From("user").Where(sb.Gt(sb.F("id"), 100)), 1).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrSubqueryArgs,
+		},
+		{
+			name: "count distinct without field",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Select().Field(sb.CountDistinct()).From("user").Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrNoField,
+		},
+		{
+			name: "delete with an empty filter struct",
+			workFn: func() (string, []any, error) {
+				type filter struct {
+					Class string `db:"class"`
+					Age   int    `db:"age"`
+				}
+				b := sb.New()
+				sql, args := b.Delete().From("t").Where(sb.EqStruct(filter{})).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrEmptyFilter,
+		},
+		{
+			name: "update with an empty filter map",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Update().Table("t").Set(sb.Set(sb.F("status"), 0)).Where(sb.EqMap(nil)).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrEmptyFilter,
+		},
+		{
+			name: "full-text search without field",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Select().Field().From("post").Where(sb.Match().Against("go", "")).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrNoField,
+		},
+		{
+			name: "full-text search without against",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Select().Field().From("post").Where(sb.Match("title")).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrNoQuery,
+		},
+		{
+			name: "full-text search with an invalid mode",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Select().Field().From("post").
+					Where(sb.Match("title").Against("go", "IN BOOLEAN MODE) OR 1=1 -- ")).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrMatchMode,
+		},
+		{
+			name: "mysql, set a json document at a path",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Update().Table("product").
+					Set(sb.Set(sb.JSON(sb.F("attrs")).Path("$.tags"), []string{"a", "b"})).
+					Where(sb.Eq(sb.F("id"), 7)).Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  "UPDATE `product` SET `attrs`=JSON_SET(`attrs`,'$.tags',CAST(? AS JSON)) WHERE `id` = ?",
+			wantArgs: []any{`["a","b"]`, 7},
+		},
+		{
+			name: "set a json value which cannot be encoded",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Update().Table("product").
+					Set(sb.Set(sb.JSON(sb.F("attrs")).Path("$.dims"), badJSON{})).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: errBadJSON,
+		},
+		{
+			name: "postgresql, two paths of a json column",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Update().Table("product").
+					Set(
+						sb.Set(sb.JSON(sb.F("attrs")).Path("$.color"), "red"),
+						sb.Set(sb.JSON(sb.F("attrs")).Path("$.size"), "xl"),
+					).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrDuplicateColumn,
+		},
+		{
+			name: "postgresql, json root as text",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Select().Field(sb.JSON(sb.F("attrs")).Path("$").Text().As("doc")).From("product").Build()
+				return sql, args, b.Err()
+			},
+			wantSql:  `SELECT "attrs"#>>'{}' AS "doc" FROM "product"`,
+			wantArgs: nil,
+		},
+		{
+			name: "update from an empty map",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Update().Table("t").SetMap(nil).Where(sb.Eq("id", 1)).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrNoColumnToUpdate,
+		},
+		{
+			name: "insert a value which is not a struct",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Insert().Into("t").Structs(1).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrInvalidColumn,
+		},
+		{
+			name: "upsert conflict key which is not inserted",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.PostgreSQL)
+				sql, args := b.Upsert().Into("config").Fields("key", "value").Values("theme", "dark").
+					ConflictOn("id").Update("value").Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrInvalidColumn,
+		},
+		{
+			name: "insert from a subquery which is not a select",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Insert().Into("t").Fields("id").Select(42).Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrSubquery,
+		},
+		{
+			name: "case without when",
+			workFn: func() (string, []any, error) {
+				b := sb.New()
+				sql, args := b.Select().Field(sb.Case().Else(0).As("c")).From("t").Build()
+				return sql, args, b.Err()
+			},
+			wantErr:   true,
+			wantErrIs: sb.ErrNoWhen,
+		},
+		{
+			name: "oracle, upsert does not update the conflict key",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.Oracle)
+				sql, args := b.Upsert().Into("config").
+					Fields("key", "value").
+					Values("theme", "dark").
+					ConflictOn("key").
+					Update("key", "value").Build()
+				return sql, args, b.Err()
+			},
+			wantSql: `MERGE INTO "config" USING (SELECT ? "key",? "value" FROM dual) "src" ON ("config"."key"="src"."key")` +
+				` WHEN MATCHED THEN UPDATE SET "value"="src"."value"` +
+				` WHEN NOT MATCHED THEN INSERT ("key","value") VALUES ("src"."key","src"."value")`,
+			wantArgs: []any{"theme", "dark"},
+		},
+		{
+			name: "sqlite, lateral is unsupported",
+			workFn: func() (string, []any, error) {
+				b := sb.New().Dialect(sb.SQLite)
+				sql, args := b.Select().Field().
+					FromT(sb.T("users", "u")).
+					CrossJoinLateral(sb.Sub("SELECT 1", nil).As("o")).Build()
+				return sql, args, b.Err()
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := tt.workFn()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Dialect err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				want := tt.wantErrIs
+				if want == nil {
+					want = sb.ErrUnsupported
+				}
+				if !errors.Is(err, want) {
+					t.Errorf("Dialect err = %v, want %v", err, want)
+				}
+				return
+			}
+			if sql != tt.wantSql {
+				t.Errorf("Dialect sql got = %v, want %v", sql, tt.wantSql)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("Dialect args got1 = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package sqlbuilder
+
+import "fmt"
+
+// Tuple groups fields into a row value to be compared with Row, e.g.
+//
+//	In(Tuple("tenant_id", "id"), Row(1, 10), Row(1, 11))
+//	Gt(Tuple("created_at", "id"), Row(t, 100))
+//
+// Dialects without row values get the comparison expanded into AND/OR conditions.
+func Tuple(fields ...any) *TupleExpr {
+	return &TupleExpr{Fields: fields}
+}
+
+type TupleExpr struct {
+	_expression
+	Fields []any
+}
+
+func (t *TupleExpr) write(buf *buffer) {
+	buf.OpenParen()
+	for i, f := range t.Fields {
+		if i > 0 {
+			buf.Comma()
+		}
+		buf.Operand(f)
+	}
+	buf.CloseParen()
+}
+
+func (t *TupleExpr) args(d Dialect) []any {
+	return fieldsArgs(d, t.Fields)
+}
+
+// Row is a row of values compared with a Tuple, its values are written as placeholders.
+func Row(values ...any) *RowValue {
+	return &RowValue{Values: values}
+}
+
+type RowValue struct {
+	_expression
+	Values []any
+}
+
+func (r *RowValue) write(buf *buffer) {
+	buf.OpenParen()
+	for i, v := range r.Values {
+		if i > 0 {
+			buf.Comma()
+		}
+		buf.Value(v)
+	}
+	buf.CloseParen()
+}
+
+func (r *RowValue) args(d Dialect) []any {
+	var args []any
+	for _, v := range r.Values {
+		args = append(args, valueArgs(d, v)...)
+	}
+	return args
+}
+
+// tupleExpansion holds the comparison of a tuple with rows expanded into AND/OR
+// conditions. The expansion does not depend on the dialect, it is made when the
+// condition is constructed and shared by write and args.
+type tupleExpansion struct {
+	cond whereCondition
+	err  error
+}
+
+// newTupleExpansion expands the comparison of field with rows, nil if field is
+// not a tuple.
+func newTupleExpansion(field any, op ConditionOperator, rows []any) *tupleExpansion {
+	if _, ok := field.(*TupleExpr); !ok {
+		return nil
+	}
+	cond, err := expandTuple(field, op, rows)
+	return &tupleExpansion{cond: cond, err: err}
+}
+
+// resolve returns the expansion to write in place of the comparison, nil if
+// field is not a tuple or the dialect supports row values. The rows are checked
+// on every dialect. A condition which is not made by its constructor has no
+// expansion, it is expanded for the call.
+func (x *tupleExpansion) resolve(d Dialect, field any, op ConditionOperator, rows []any) (whereCondition, error) {
+	if x == nil {
+		if x = newTupleExpansion(field, op, rows); x == nil {
+			return nil, nil
+		}
+	}
+	if x.err != nil || d.supportsRowValues(op) {
+		return nil, x.err
+	}
+	if x.cond == nil {
+		return nil, unsupported(d, "row value "+string(op))
+	}
+	return x.cond, nil
+}
+
+// expandTuple checks that the rows compared with the tuple are rows of as many
+// values, and returns the conditions equivalent to the comparison, nil if field
+// is not a tuple or op cannot be expanded.
+func expandTuple(field any, op ConditionOperator, rows []any) (whereCondition, error) {
+	t, ok := field.(*TupleExpr)
+	if !ok {
+		return nil, nil
+	}
+	values := make([][]any, 0, len(rows))
+	for _, v := range rows {
+		row, ok := v.(*RowValue)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T compared to a tuple of %d fields, expect a Row", ErrColumnCount, v, len(t.Fields))
+		}
+		if len(row.Values) != len(t.Fields) {
+			return nil, fmt.Errorf("%w: row of %d values compared to a tuple of %d fields", ErrColumnCount, len(row.Values), len(t.Fields))
+		}
+		values = append(values, row.Values)
+	}
+	switch op {
+	case InOperator, NotInOperator:
+		alternatives := make([]whereCondition, len(values))
+		for i, v := range values {
+			alternatives[i] = compareTuple(t.Fields, op, v)
+		}
+		if op == NotInOperator {
+			return Not(group(OrOperator, alternatives)), nil
+		}
+		return group(OrOperator, alternatives), nil
+	case EqOperator, NeOperator, LtOperator, LeOperator, GtOperator, GeOperator:
+		if len(values) == 1 {
+			return compareTuple(t.Fields, op, values[0]), nil
+		}
+	}
+	return nil, nil
+}
+
+// compareTuple expands (f1, f2, ...) op (v1, v2, ...), an ordering is compared
+// lexicographically, e.g. (a, b) > (x, y) is a > x OR (a = x AND b > y).
+func compareTuple(fields []any, op ConditionOperator, values []any) whereCondition {
+	conditions := make([]whereCondition, len(fields))
+	switch op {
+	case EqOperator, InOperator, NotInOperator:
+		for i := range fields {
+			conditions[i] = Eq(fields[i], values[i])
+		}
+		return group(AndOperator, conditions)
+	case NeOperator:
+		for i := range fields {
+			conditions[i] = Ne(fields[i], values[i])
+		}
+		return group(OrOperator, conditions)
+	}
+	strict := op
+	if op == LeOperator {
+		strict = LtOperator
+	} else if op == GeOperator {
+		strict = GtOperator
+	}
+	for i := range fields {
+		term := make([]whereCondition, 0, i+1)
+		for j := 0; j < i; j++ {
+			term = append(term, Eq(fields[j], values[j]))
+		}
+		last := strict
+		if i == len(fields)-1 {
+			last = op
+		}
+		term = append(term, &BinaryCondition{Field: fields[i], Op: last, Args: []any{values[i]}})
+		conditions[i] = group(AndOperator, term)
+	}
+	return group(OrOperator, conditions)
+}
+
+// group joins the conditions with op, a single condition is returned as is.
+func group(op ConditionOperator, conditions []whereCondition) whereCondition {
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+	return &BoolCondition{Op: op, Conditions: conditions}
+}
@@ -4,6 +4,10 @@ type updateBuilder SqlBuilder
 
 type updateBuilderTable SqlBuilder
 
+type updateBuilderJoin SqlBuilder
+
+type updateBuilderJoinSpec SqlBuilder
+
 type updateBuilderSet SqlBuilder
 
 type updateBuilderWhere SqlBuilder
@@ -12,6 +16,8 @@ type updateBuilderOrder SqlBuilder
 
 type updateBuilderLimit SqlBuilder
 
+type updateBuilderReturning SqlBuilder
+
 func (b *updateBuilder) init(kws []Keyword) *updateBuilder {
 	b.buf.WriteString("UPDATE")
 	for _, kw := range kws {
@@ -23,41 +29,158 @@ func (b *updateBuilder) init(kws []Keyword) *updateBuilder {
 
 func (b *updateBuilder) Table(table string) *updateBuilderTable {
 	b.buf.Space()
-	b.buf.BackQuoteString(table)
+	b.buf.Quote(table)
+	b.target = T(table)
 	return (*updateBuilderTable)(b)
 }
 
 func (b *updateBuilder) TableT(table *Table) *updateBuilderTable {
 	b.buf.Space()
 	b.buf.Table(table)
+	b.target = table
 	return (*updateBuilderTable)(b)
 }
 
+func (b *updateBuilderTable) LeftJoin(table *Table) *updateBuilderJoin {
+	return (*updateBuilderJoin)(b).join(leftJoin, table)
+}
+
+func (b *updateBuilderTable) RightJoin(table *Table) *updateBuilderJoin {
+	return (*updateBuilderJoin)(b).join(rightJoin, table)
+}
+
+func (b *updateBuilderTable) InnerJoin(table *Table) *updateBuilderJoin {
+	return (*updateBuilderJoin)(b).join(innerJoin, table)
+}
+
+func (b *updateBuilderTable) CrossJoin(table *Table) *updateBuilderJoinSpec {
+	return (*updateBuilderJoinSpec)(b).join(crossJoin, table)
+}
+
+// Set writes the assignments. A multi-table update is rendered as
+// `UPDATE ... SET ... FROM ...` on PostgreSQL and SQLite, the first joined
+// table has to be an inner or cross join there.
 func (b *updateBuilderTable) Set(vps ...valueUpdater) *updateBuilderSet {
+	var (
+		from     string
+		fromArgs []any
+	)
+	if b.from != nil {
+		from, fromArgs = (*SqlBuilder)(b).cut(b.from.mark, b.from.argMark)
+	}
 	b.buf.Space()
 	b.buf.WriteString("SET")
 	b.buf.Space()
 	b.buf.ValueUpdater(vps)
 	for i := range vps {
-		b.args = append(b.args, vps[i].args()...)
+		b.args = append(b.args, vps[i].args(b.dialect)...)
+	}
+	(*SqlBuilder)(b).markOutput()
+	if b.from != nil {
+		b.buf.Space()
+		b.buf.WriteString("FROM")
+		b.buf.WriteString(from)
+		b.args = append(b.args, fromArgs...)
+		(*SqlBuilder)(b).whereFrom()
 	}
 	return (*updateBuilderSet)(b)
 }
 
-func (b *updateBuilderSet) Where(conditions ...whereCondition) *updateBuilderWhere {
-	if len(conditions) == 0 {
-		return (*updateBuilderWhere)(b)
+// SetMap assigns the values of m to the columns named by its keys, in ascending key order.
+func (b *updateBuilderTable) SetMap(m map[string]any) *updateBuilderSet {
+	pairs := mapPairs(m)
+	vps := make([]valueUpdater, len(pairs))
+	for i := range pairs {
+		vps[i] = pairs[i]
 	}
-	b.buf.Space()
-	b.buf.WriteString("WHERE")
-	b.buf.Space()
-	b.buf.Conditions(conditions)
-	for i := range conditions {
-		b.args = append(b.args, conditions[i].args()...)
+	return b.setColumns(vps)
+}
+
+// SetStruct assigns the fields of the struct v tagged with `db:"column"`, in declaration order.
+func (b *updateBuilderTable) SetStruct(v any, opts ...StructOption) *updateBuilderSet {
+	columns, values, err := structColumns(v, opts)
+	if err != nil {
+		b.buf.setErr(err)
+	}
+	vps := make([]valueUpdater, 0, len(columns))
+	for i := range columns {
+		vps = append(vps, Set(F(columns[i]), values[i]))
 	}
+	return b.setColumns(vps)
+}
+
+func (b *updateBuilderTable) setColumns(vps []valueUpdater) *updateBuilderSet {
+	if len(vps) == 0 {
+		b.buf.setErr(ErrNoColumnToUpdate)
+	}
+	return b.Set(vps...)
+}
+
+func (b *updateBuilderJoin) join(joinType Keyword, table *Table) *updateBuilderJoin {
+	(*SqlBuilder)(b).joinFrom("UPDATE", joinType, table, "")
+	return b
+}
+
+func (b *updateBuilderJoin) On(lhs, rhs *Field) *updateBuilderJoinSpec {
+	(*SqlBuilder)(b).on(lhs, rhs)
+	return (*updateBuilderJoinSpec)(b)
+}
+
+func (b *updateBuilderJoin) OnCondition(conditions ...whereCondition) *updateBuilderJoinSpec {
+	(*SqlBuilder)(b).onConditions(conditions)
+	return (*updateBuilderJoinSpec)(b)
+}
+
+func (b *updateBuilderJoin) Using(fields ...string) *updateBuilderJoinSpec {
+	(*SqlBuilder)(b).using(fields)
+	return (*updateBuilderJoinSpec)(b)
+}
+
+func (b *updateBuilderJoinSpec) join(joinType Keyword, table *Table) *updateBuilderJoinSpec {
+	(*SqlBuilder)(b).joinFrom("UPDATE", joinType, table, "")
+	return b
+}
+
+func (b *updateBuilderJoinSpec) LeftJoin(table *Table) *updateBuilderJoin {
+	return (*updateBuilderJoin)(b).join(leftJoin, table)
+}
+
+func (b *updateBuilderJoinSpec) RightJoin(table *Table) *updateBuilderJoin {
+	return (*updateBuilderJoin)(b).join(rightJoin, table)
+}
+
+func (b *updateBuilderJoinSpec) InnerJoin(table *Table) *updateBuilderJoin {
+	return (*updateBuilderJoin)(b).join(innerJoin, table)
+}
+
+func (b *updateBuilderJoinSpec) CrossJoin(table *Table) *updateBuilderJoinSpec {
+	return b.join(crossJoin, table)
+}
+
+func (b *updateBuilderJoinSpec) Set(vps ...valueUpdater) *updateBuilderSet {
+	return (*updateBuilderTable)(b).Set(vps...)
+}
+
+func (b *updateBuilderJoinSpec) SetMap(m map[string]any) *updateBuilderSet {
+	return (*updateBuilderTable)(b).SetMap(m)
+}
+
+func (b *updateBuilderJoinSpec) SetStruct(v any, opts ...StructOption) *updateBuilderSet {
+	return (*updateBuilderTable)(b).SetStruct(v, opts...)
+}
+
+func (b *updateBuilderSet) Where(conditions ...whereCondition) *updateBuilderWhere {
+	(*SqlBuilder)(b).where(conditions)
 	return (*updateBuilderWhere)(b)
 }
 
+// Returning returns the given fields of the updated rows, or all columns when
+// no field is given. It is rendered as `OUTPUT INSERTED.field` on SQL Server and
+// is not supported by MySQL, MariaDB and Oracle.
+func (b *updateBuilderSet) Returning(fields ...any) *updateBuilderReturning {
+	return (*updateBuilderReturning)(b).returning(fields)
+}
+
 func (b *updateBuilderSet) Build() (string, []any) {
 	return (*sqlBuilderBuild)(b).Build()
 }
@@ -70,6 +193,10 @@ func (b *updateBuilderWhere) Limit(limit any) *updateBuilderLimit {
 	return (*updateBuilderLimit)(b).limit(limit)
 }
 
+func (b *updateBuilderWhere) Returning(fields ...any) *updateBuilderReturning {
+	return (*updateBuilderReturning)(b).returning(fields)
+}
+
 func (b *updateBuilderWhere) Build() (string, []any) {
 	return (*sqlBuilderBuild)(b).Build()
 }
@@ -79,6 +206,7 @@ func (b *updateBuilderOrder) order(orderSpecs []*OrderSpec) *updateBuilderOrder
 	b.buf.WriteString("ORDER BY")
 	b.buf.Space()
 	b.buf.OrderSpecs(orderSpecs)
+	b.args = append(b.args, orderArgs(b.dialect, orderSpecs)...)
 	return b
 }
 
@@ -102,3 +230,12 @@ func (b *updateBuilderLimit) limit(limit any) *updateBuilderLimit {
 func (b *updateBuilderLimit) Build() (string, []any) {
 	return (*sqlBuilderBuild)(b).Build()
 }
+
+func (b *updateBuilderReturning) returning(fields []any) *updateBuilderReturning {
+	(*SqlBuilder)(b).returning("UPDATE", fields)
+	return b
+}
+
+func (b *updateBuilderReturning) Build() (string, []any) {
+	return (*sqlBuilderBuild)(b).Build()
+}
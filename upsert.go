@@ -0,0 +1,294 @@
+package sqlbuilder
+
+import "fmt"
+
+// upsertSource is the alias of the inserted rows in a MERGE statement.
+const upsertSource = "src"
+
+type upsertBuilder SqlBuilder
+
+type upsertBuilderTable SqlBuilder
+
+type upsertBuilderFields SqlBuilder
+
+type upsertBuilderValues SqlBuilder
+
+type upsertBuilderConflict SqlBuilder
+
+type upsertBuilderUpdate SqlBuilder
+
+// upsertClause records an upsert, which is only rendered once the dialect
+// specific parts are known. The args of the rows are collected when they are written.
+type upsertClause struct {
+	rows [][]any
+	keys []string
+}
+
+func (b *upsertBuilder) init() *upsertBuilder {
+	b.upsert = &upsertClause{}
+	return b
+}
+
+func (b *upsertBuilder) Into(table string) *upsertBuilderTable {
+	return b.IntoT(T(table))
+}
+
+func (b *upsertBuilder) IntoT(table *Table) *upsertBuilderTable {
+	b.target = table
+	return (*upsertBuilderTable)(b)
+}
+
+func (b *upsertBuilderTable) Fields(fields ...string) *upsertBuilderFields {
+	b.columns = fields
+	return (*upsertBuilderFields)(b)
+}
+
+// Structs upserts rows read from the fields tagged with `db:"column"`, see insertBuilderTable.Structs.
+func (b *upsertBuilderTable) Structs(rows any) *upsertBuilderValues {
+	n, columns, argf, err := structRows(rows)
+	if err != nil {
+		b.buf.setErr(err)
+		return (*upsertBuilderValues)(b)
+	}
+	return b.Fields(columns...).Bulk(n, argf)
+}
+
+// Values upserts a row, a value is an arg or an expression such as Default or Raw,
+// see insertBuilderFields.Values.
+func (b *upsertBuilderFields) Values(args ...any) *upsertBuilderValues {
+	b.checkWidth(args)
+	b.upsert.rows = [][]any{args}
+	return (*upsertBuilderValues)(b)
+}
+
+func (b *upsertBuilderFields) Bulk(n int, argf func(index int) []any) *upsertBuilderValues {
+	if n <= 0 {
+		b.buf.setErr(ErrNoRows)
+	}
+	b.upsert.rows = make([][]any, 0, n)
+	for i := 0; i < n; i++ {
+		args := argf(i)
+		b.checkWidth(args)
+		b.upsert.rows = append(b.upsert.rows, args)
+	}
+	return (*upsertBuilderValues)(b)
+}
+
+func (b *upsertBuilderFields) checkWidth(row []any) {
+	if len(row) != len(b.columns) {
+		b.buf.setErr(fmt.Errorf("%w: %d values are given for %d columns", ErrColumnCount, len(row), len(b.columns)))
+	}
+}
+
+// ConflictOn specifies the unique columns identifying an existing row. MySQL
+// detects the conflict on any unique index and does not render them.
+func (b *upsertBuilderValues) ConflictOn(keys ...string) *upsertBuilderConflict {
+	inserted := make(map[string]bool, len(b.columns))
+	for _, c := range b.columns {
+		inserted[c] = true
+	}
+	for _, k := range keys {
+		if !inserted[k] {
+			b.buf.setErr(fmt.Errorf("%w: conflict key %q is not inserted", ErrInvalidColumn, k))
+		}
+	}
+	b.upsert.keys = keys
+	return (*upsertBuilderConflict)(b)
+}
+
+// Update updates the given columns of the conflicting row from the row proposed
+// for insertion, every inserted column when none is given. The keys are never
+// updated, MERGE rejects the update of the columns it matches on. It is rendered
+// as ON DUPLICATE KEY UPDATE on MySQL, as ON CONFLICT on PostgreSQL and SQLite
+// and as MERGE on SQL Server and Oracle.
+func (b *upsertBuilderConflict) Update(columns ...string) *upsertBuilderUpdate {
+	if len(columns) == 0 {
+		columns = b.columns
+	}
+	columns = b.updateColumns(columns)
+	if len(columns) == 0 {
+		b.buf.setErr(ErrNoColumnToUpdate)
+	}
+	switch {
+	case b.dialect.isMySQL():
+		b.insert()
+		b.keyword("ON DUPLICATE KEY UPDATE")
+		UpdateFromInsert(columns...).write(b.buf)
+	case b.dialect == PostgreSQL || b.dialect == SQLite:
+		if len(b.upsert.keys) == 0 {
+			b.buf.setErr(ErrNoConflictKey)
+		}
+		b.insert()
+		b.keyword("ON CONFLICT")
+		b.buf.OpenParen()
+		b.buf.Quotes(b.upsert.keys)
+		b.buf.CloseParen()
+		b.keyword("DO UPDATE SET")
+		UpdateFromInsert(columns...).write(b.buf)
+	default:
+		if len(b.upsert.keys) == 0 {
+			b.buf.setErr(ErrNoConflictKey)
+		}
+		b.merge(columns)
+	}
+	return (*upsertBuilderUpdate)(b)
+}
+
+// updateColumns returns the columns but the keys.
+func (b *upsertBuilderConflict) updateColumns(columns []string) []string {
+	skip := make(map[string]bool, len(b.upsert.keys))
+	for _, k := range b.upsert.keys {
+		skip[k] = true
+	}
+	updated := make([]string, 0, len(columns))
+	for _, c := range columns {
+		if !skip[c] {
+			updated = append(updated, c)
+		}
+	}
+	return updated
+}
+
+// keyword writes kw between spaces.
+func (b *upsertBuilderConflict) keyword(kw string) {
+	b.buf.Space()
+	b.buf.WriteString(kw)
+	b.buf.Space()
+}
+
+func (b *upsertBuilderConflict) insert() {
+	b.buf.WriteString("INSERT")
+	b.keyword("INTO")
+	b.buf.Table(b.target)
+	b.buf.Space()
+	b.buf.OpenParen()
+	b.buf.Quotes(b.columns)
+	b.buf.CloseParen()
+	b.keyword("VALUES")
+	b.rows()
+}
+
+// rows writes the VALUES list of the rows.
+func (b *upsertBuilderConflict) rows() {
+	for i, row := range b.upsert.rows {
+		if i > 0 {
+			b.buf.Comma()
+		}
+		b.args = append(b.args, b.buf.Row(row)...)
+	}
+}
+
+// merge writes
+//
+//	MERGE INTO t USING (VALUES ...) AS src (columns) ON (t.key=src.key)
+//	WHEN MATCHED THEN UPDATE SET col=src.col
+//	WHEN NOT MATCHED THEN INSERT (columns) VALUES (src.columns)
+//
+// Oracle selects the rows from dual instead of using a VALUES list.
+func (b *upsertBuilderConflict) merge(columns []string) {
+	b.buf.WriteString("MERGE")
+	b.keyword("INTO")
+	b.buf.Table(b.target)
+	b.keyword("USING")
+	b.buf.OpenParen()
+	for _, row := range b.upsert.rows {
+		for _, v := range row {
+			if _, ok := v.(*DefaultValue); ok {
+				// The source of a MERGE is a derived table, which has no default.
+				b.buf.setErr(unsupported(b.dialect, "DEFAULT in MERGE"))
+			}
+		}
+	}
+	switch b.dialect {
+	case SQLServer:
+		b.buf.WriteString("VALUES")
+		b.buf.Space()
+		b.rows()
+		b.buf.CloseParen()
+		b.buf.Alias(upsertSource)
+		b.buf.Space()
+		b.buf.OpenParen()
+		b.buf.Quotes(b.columns)
+		b.buf.CloseParen()
+	case Oracle:
+		for i, row := range b.upsert.rows {
+			if i > 0 {
+				b.keyword("UNION ALL")
+			}
+			b.buf.WriteString("SELECT")
+			b.buf.Space()
+			for j, c := range b.columns {
+				if j > 0 {
+					b.buf.Comma()
+				}
+				if j < len(row) {
+					b.buf.Value(row[j])
+				}
+				b.buf.Space()
+				b.buf.Quote(c)
+			}
+			b.keyword("FROM")
+			b.buf.WriteString("dual")
+			b.args = append(b.args, valuesArgs(b.dialect, row)...)
+		}
+		b.buf.CloseParen()
+		b.buf.TableAlias(upsertSource)
+	default:
+		b.buf.setErr(unsupported(b.dialect, "upsert"))
+		b.buf.CloseParen()
+	}
+
+	b.keyword("ON")
+	b.buf.OpenParen()
+	for i, k := range b.upsert.keys {
+		if i > 0 {
+			b.buf.Space()
+			b.buf.WriteString(string(AndOperator))
+			b.buf.Space()
+		}
+		b.buf.TableRef(b.target)
+		b.buf.Dot()
+		b.buf.Quote(k)
+		b.buf.Equal()
+		b.source(k)
+	}
+	b.buf.CloseParen()
+
+	b.keyword("WHEN MATCHED THEN UPDATE SET")
+	for i, c := range columns {
+		if i > 0 {
+			b.buf.Comma()
+		}
+		b.buf.Quote(c)
+		b.buf.Equal()
+		b.source(c)
+	}
+
+	b.keyword("WHEN NOT MATCHED THEN INSERT")
+	b.buf.OpenParen()
+	b.buf.Quotes(b.columns)
+	b.buf.CloseParen()
+	b.keyword("VALUES")
+	b.buf.OpenParen()
+	for i, c := range b.columns {
+		if i > 0 {
+			b.buf.Comma()
+		}
+		b.source(c)
+	}
+	b.buf.CloseParen()
+	// SQL Server requires MERGE to be terminated.
+	if b.dialect == SQLServer {
+		b.buf.WriteByte(';')
+	}
+}
+
+func (b *upsertBuilderConflict) source(column string) {
+	b.buf.Quote(upsertSource)
+	b.buf.Dot()
+	b.buf.Quote(column)
+}
+
+func (b *upsertBuilderUpdate) Build() (string, []any) {
+	return (*sqlBuilderBuild)(b).Build()
+}
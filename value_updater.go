@@ -6,7 +6,7 @@ type _valueUpdater interface {
 
 type valueUpdater interface {
 	_valueUpdater
-	args() []any
+	args(d Dialect) []any
 	write(*buffer)
 }
 
@@ -24,6 +24,55 @@ func Value(expr string, args ...any) *Valuer {
 	}
 }
 
+// Incr increases the column by n, e.g. Incr("views", 1) is rendered as `views=views+?`.
+func Incr(field any, n any) *ArithValuer {
+	return &ArithValuer{Field: field, Op: "+", Arg: n}
+}
+
+// Decr decreases the column by n, e.g. Decr("stock", 1) is rendered as `stock=stock-?`.
+func Decr(field any, n any) *ArithValuer {
+	return &ArithValuer{Field: field, Op: "-", Arg: n}
+}
+
+// SetExpr assigns an SQL expression to the column, e.g. SetExpr("updated_at", "NOW()").
+func SetExpr(field any, expr string, args ...any) *SetValuer {
+	return Set(field, Raw(expr, args...))
+}
+
+// SetColumn assigns the value of another column, e.g. SetColumn("a", "b") is rendered as `a=b`.
+func SetColumn(field any, other any) *SetValuer {
+	if s, ok := other.(string); ok {
+		other = F(s)
+	}
+	return Set(field, other)
+}
+
+// SetNull assigns NULL to the column.
+func SetNull(field any) *SetValuer {
+	return Set(field, Raw("NULL"))
+}
+
+// mapPairs converts m into assignments in ascending key order.
+func mapPairs(m map[string]any) []*SetValuer {
+	keys := sortedKeys(m)
+	pairs := make([]*SetValuer, len(keys))
+	for i, k := range keys {
+		pairs[i] = Set(F(k), m[k])
+	}
+	return pairs
+}
+
+// columnName returns the bare column name of a field.
+func columnName(field any) string {
+	switch v := field.(type) {
+	case *Field:
+		return v.Field
+	case string:
+		return v
+	}
+	return ""
+}
+
 type SetValuer struct {
 	_valueUpdater
 	Field any
@@ -31,15 +80,88 @@ type SetValuer struct {
 }
 
 func (v *SetValuer) write(buf *buffer) {
-	buf.AnyField(v.Field)
+	if j, ok := v.Field.(*JSONExpr); ok {
+		j.writeSet(buf, func() {
+			if v.column() == nil && v.expr() == nil && len(v.Args) == 1 {
+				j.writeSetArg(buf, v.Args[0])
+				return
+			}
+			v.writeValue(buf)
+		})
+		return
+	}
+	buf.SetTarget(v.Field)
 	buf.Equal()
-	buf.Question()
+	v.writeValue(buf)
 }
 
-func (v *SetValuer) args() []any {
+func (v *SetValuer) writeValue(buf *buffer) {
+	if f := v.column(); f != nil {
+		buf.ValueField(f)
+	} else if e := v.expr(); e != nil {
+		e.write(buf)
+	} else {
+		buf.Question()
+	}
+}
+
+func (v *SetValuer) args(d Dialect) []any {
+	if v.column() != nil {
+		return nil
+	}
+	if e := v.expr(); e != nil {
+		return e.args(d)
+	}
+	if j, ok := v.Field.(*JSONExpr); ok && len(v.Args) == 1 {
+		return append(j.args(d), j.setArg(d, v.Args[0]))
+	}
 	return v.Args
 }
 
+// column returns the field when the value is another column, e.g. Set(F("o", "tier"), F("u", "tier")).
+func (v *SetValuer) column() *Field {
+	if len(v.Args) != 1 {
+		return nil
+	}
+	f, _ := v.Args[0].(*Field)
+	return f
+}
+
+// expr returns the expression when the value is rendered by itself, e.g. Set("total", Excluded("total")).
+func (v *SetValuer) expr() expression {
+	if len(v.Args) != 1 {
+		return nil
+	}
+	e, _ := v.Args[0].(expression)
+	return e
+}
+
+// UpdateFromInsert assigns each column from the row proposed for insertion,
+// see Excluded.
+func UpdateFromInsert(columns ...string) *InsertedValuer {
+	return &InsertedValuer{Columns: columns}
+}
+
+type InsertedValuer struct {
+	_valueUpdater
+	Columns []string
+}
+
+func (v *InsertedValuer) write(buf *buffer) {
+	for i, c := range v.Columns {
+		if i > 0 {
+			buf.Comma()
+		}
+		buf.Quote(c)
+		buf.Equal()
+		buf.Inserted(c)
+	}
+}
+
+func (v *InsertedValuer) args(d Dialect) []any {
+	return nil
+}
+
 type Valuer struct {
 	_valueUpdater
 	Expr string
@@ -50,6 +172,32 @@ func (v *Valuer) write(buf *buffer) {
 	buf.WriteString(v.Expr)
 }
 
-func (v *Valuer) args() []any {
+func (v *Valuer) args(d Dialect) []any {
 	return v.Args
 }
+
+type ArithValuer struct {
+	_valueUpdater
+	Field any
+	Op    string
+	Arg   any
+}
+
+func (v *ArithValuer) write(buf *buffer) {
+	buf.SetTarget(v.Field)
+	buf.Equal()
+	switch f := v.Field.(type) {
+	case *Field:
+		buf.ValueField(f)
+	case string:
+		buf.ValueField(F(f))
+	default:
+		buf.AnyField(f)
+	}
+	buf.WriteString(v.Op)
+	buf.Question()
+}
+
+func (v *ArithValuer) args(d Dialect) []any {
+	return []any{v.Arg}
+}